@@ -0,0 +1,195 @@
+package pickem
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// maxRestDays caps the days of rest a team gets before a game.  Teams playing their first game of a set get the maximum.
+const maxRestDays = 14
+
+// TeamConditions describe the circumstances under which a team plays a game.
+type TeamConditions struct {
+	// Travel is the great-circle distance in miles from the team's home venue to the game's venue.
+	Travel float64
+	// Climb is the elevation of the game's venue above the team's home venue, in the units of Venue.LatLonAlt.
+	Climb float64
+	// Rest is the number of days since the team's previous game, at most maxRestDays.
+	Rest float64
+}
+
+// Conditions are the TeamConditions of both teams of a Matchup.
+type Conditions struct {
+	Team1 TeamConditions
+	Team2 TeamConditions
+}
+
+// Swap returns the Conditions with the teams exchanged, as for the Matchup seen from the second team.
+func (c Conditions) Swap() Conditions {
+	return Conditions{Team1: c.Team2, Team2: c.Team1}
+}
+
+// features returns the differences between the teams' conditions that favor the first team:  extra miles traveled by the
+// second team, extra climb above home by the second team, and extra days of rest for the first team.  Descending to a
+// venue lower than home is assumed to have no effect.
+func (c Conditions) features() [3]float64 {
+	return [3]float64{
+		c.Team2.Travel - c.Team1.Travel,
+		math.Max(0, c.Team2.Climb) - math.Max(0, c.Team1.Climb),
+		c.Team1.Rest - c.Team2.Rest,
+	}
+}
+
+// Adjustment converts the Conditions of a Matchup into points added to the first team's spread.
+type Adjustment struct {
+	// PerMile is the number of points per mile the second team travels farther than the first team.
+	PerMile float64
+	// PerClimb is the number of points per unit of elevation the second team climbs above its home venue more than the first team.
+	PerClimb float64
+	// PerRestDay is the number of points per extra day of rest the first team has.
+	PerRestDay float64
+}
+
+// Points returns the number of points added to the first team's spread under the given conditions.
+func (a Adjustment) Points(c Conditions) float64 {
+	f := c.features()
+	return a.PerMile*f[0] + a.PerClimb*f[1] + a.PerRestDay*f[2]
+}
+
+// SetAdjustment sets the coefficients that convert the Conditions of a Matchup into points of spread, which Predict adds to the
+// spread of every Matchup.
+func (m *GaussianSpreadModel) SetAdjustment(a Adjustment) {
+	m.adjustment = a
+}
+
+// teamConditions measures the travel and climb of a team from its home venue to a game's venue.  Either is zero if a venue
+// or its coordinates are missing.
+func teamConditions(home, site *Venue) TeamConditions {
+	var tc TeamConditions
+	if home == nil || site == nil {
+		return tc
+	}
+	if d, err := home.Distance(site); err == nil {
+		tc.Travel = d
+	}
+	if len(home.LatLonAlt) >= 3 && len(site.LatLonAlt) >= 3 {
+		tc.Climb = site.LatLonAlt[2] - home.LatLonAlt[2]
+	}
+	return tc
+}
+
+// GameConditions measures the Conditions of each Game, keyed by game ID, with the home team as the first team.  Use Swap to
+// orient them to the away team before predicting a Matchup with the away team first.
+//
+// Travel and climb are measured from each team's home venue to the game's venue, with the Teams and Venues loaded from the
+// Store in one query each.  Rest is measured from each team's previous game among the given Games, so the Games should include
+// every game of the teams' seasons.
+func GameConditions(ctx context.Context, s Store, games map[string]*Game) (map[string]Conditions, error) {
+	teams, err := s.Teams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	venues, err := s.Venues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return gameConditions(games, teams, venues)
+}
+
+// gameConditions implements GameConditions with the Teams and Venues referenced by the Games looked up by document ID.
+func gameConditions(games map[string]*Game, teams map[string]*Team, venues map[string]*Venue) (map[string]Conditions, error) {
+	homeOf := func(teamID string) (*Venue, error) {
+		t, ok := teams[teamID]
+		if !ok {
+			return nil, fmt.Errorf("team '%s' not found", teamID)
+		}
+		if t.HomeVenue == nil {
+			return nil, nil
+		}
+		return venues[t.HomeVenue.ID], nil
+	}
+
+	lastPlayed := make(map[string]time.Time)
+	rest := func(teamID string, start time.Time) float64 {
+		last, ok := lastPlayed[teamID]
+		lastPlayed[teamID] = start
+		if !ok {
+			return maxRestDays
+		}
+		return math.Min(maxRestDays, start.Sub(last).Hours()/24)
+	}
+
+	conditions := make(map[string]Conditions, len(games))
+	for _, id := range gameIDsByStart(games) {
+		g := games[id]
+		var site *Venue
+		if g.Venue != nil {
+			site = venues[g.Venue.ID]
+		}
+		home, err := homeOf(g.HomeTeam.ID)
+		if err != nil {
+			return nil, err
+		}
+		away, err := homeOf(g.AwayTeam.ID)
+		if err != nil {
+			return nil, err
+		}
+		c := Conditions{Team1: teamConditions(home, site), Team2: teamConditions(away, site)}
+		c.Team1.Rest = rest(g.HomeTeam.ID, g.StartTime)
+		c.Team2.Rest = rest(g.AwayTeam.ID, g.StartTime)
+		conditions[id] = c
+	}
+	return conditions, nil
+}
+
+// FitAdjustment fits the coefficients of an Adjustment by least squares to the part of each completed Game's margin that the
+// model's ratings and location biases do not explain.  Conditions are keyed by game ID, as returned by GameConditions, and
+// completed games without conditions are ignored.  A coefficient is fixed at zero if its condition never differs between teams.
+// Games become Matchups as in FitElo, so the venues map may be nil.
+func FitAdjustment(m *GaussianSpreadModel, games map[string]*Game, teams map[string]*Team, venues map[string]*Venue, conditions map[string]Conditions) (Adjustment, error) {
+	a := make([][]float64, 3)
+	for i := range a {
+		a[i] = make([]float64, 3)
+	}
+	b := make([]float64, 3)
+	n := 0
+	for _, id := range gameIDsByStart(games) {
+		g := games[id]
+		c, ok := conditions[id]
+		if !ok || !g.completed() {
+			continue
+		}
+		mu, err := gameMatchup(g, teams, venues)
+		if err != nil {
+			return Adjustment{}, err
+		}
+		base, err := m.baseSpread(mu.Team1, mu.Team2, mu.Location)
+		if err != nil {
+			return Adjustment{}, err
+		}
+		r := g.margin() - base
+		x := c.features()
+		for i := range x {
+			b[i] += x[i] * r
+			for j := range x {
+				a[i][j] += x[i] * x[j]
+			}
+		}
+		n++
+	}
+	if n == 0 {
+		return Adjustment{}, fmt.Errorf("no completed games with conditions to fit")
+	}
+	for i := range a {
+		if a[i][i] == 0 {
+			a[i][i] = 1
+		}
+	}
+	x, err := solveLinear(a, b)
+	if err != nil {
+		return Adjustment{}, fmt.Errorf("cannot fit adjustment: %v", err)
+	}
+	return Adjustment{PerMile: x[0], PerClimb: x[1], PerRestDay: x[2]}, nil
+}
@@ -0,0 +1,189 @@
+package pickem
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGaussianSpreadModel_Adjustment(t *testing.T) {
+	teamA := fakeTeam("A")
+	teamB := fakeTeam("B")
+	m := NewGaussianSpreadModel(map[*Team]float64{teamA: 3, teamB: 1}, 10, 2, 1)
+	m.SetAdjustment(Adjustment{PerMile: .01, PerClimb: .002, PerRestDay: .5})
+
+	_, before, err := m.Predict(Matchup{Team1: teamA, Team2: teamB, Location: Home})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before != 4 {
+		t.Errorf("expected no adjustment without conditions, got spread %v", before)
+	}
+
+	c := Conditions{
+		Team1: TeamConditions{Travel: 0, Climb: 0, Rest: 7},
+		Team2: TeamConditions{Travel: 500, Climb: 1000, Rest: 5},
+	}
+	wantPoints := .01*500 + .002*1000 + .5*2
+	if got := m.adjustment.Points(c); math.Abs(got-wantPoints) > 1e-12 {
+		t.Errorf("Points() = %v, want %v", got, wantPoints)
+	}
+	swapped := c.Swap()
+	if swapped.Team1 != c.Team2 || swapped.Team2 != c.Team1 {
+		t.Errorf("Swap() = %+v, want teams exchanged", swapped)
+	}
+	if got := m.adjustment.Points(swapped); math.Abs(got+wantPoints) > 1e-12 {
+		t.Errorf("expected swapped conditions to negate points, got %v", got)
+	}
+
+	_, spread, err := m.Predict(Matchup{Team1: teamA, Team2: teamB, Location: Home, Conditions: c})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(spread-(4+wantPoints)) > 1e-12 {
+		t.Errorf("spread = %v, want %v", spread, 4+wantPoints)
+	}
+	_, spread, err = m.Predict(Matchup{Team1: teamB, Team2: teamA, Location: Away, Conditions: swapped})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(spread+(4+wantPoints)) > 1e-12 {
+		t.Errorf("reversed spread = %v, want %v", spread, -(4 + wantPoints))
+	}
+
+	// Conditions belong to a single game, so other games between the same teams are not adjusted.
+	if _, spread, err = m.Predict(Matchup{Team1: teamA, Team2: teamB, Location: Home}); err != nil || spread != 4 {
+		t.Errorf("expected no adjustment without conditions, got spread %v (%v)", spread, err)
+	}
+
+	// Descending below home does not help the first team.
+	down := Conditions{Team1: TeamConditions{Climb: -1000}, Team2: TeamConditions{}}
+	if got := m.adjustment.Points(down); got != 0 {
+		t.Errorf("expected descending to have no effect, got %v", got)
+	}
+}
+
+func TestGameConditions(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+	games, err := s.Games(ctx, GameQuery{Season: 2019})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	for _, g := range games {
+		g.StartTime = start.AddDate(0, 0, 7*(g.Week-1))
+	}
+	games["105"].StartTime = games["105"].StartTime.AddDate(0, 0, 14)
+
+	conditions, err := GameConditions(ctx, s, games)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alphaField, _ := s.Venue(ctx, "10")
+	betaBowl, _ := s.Venue(ctx, "20")
+	neutral, _ := s.Venue(ctx, "99")
+	dist := func(a, b *Venue) float64 {
+		d, err := a.Distance(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	c := conditions["101"]
+	if c.Team1 != (TeamConditions{Travel: 0, Climb: 0, Rest: maxRestDays}) {
+		t.Errorf("unexpected home conditions for 101: %+v", c.Team1)
+	}
+	if math.Abs(c.Team2.Travel-dist(betaBowl, alphaField)) > 1e-9 || c.Team2.Climb != -20 || c.Team2.Rest != maxRestDays {
+		t.Errorf("unexpected away conditions for 101: %+v", c.Team2)
+	}
+
+	c = conditions["102"]
+	if math.Abs(c.Team2.Travel-dist(alphaField, neutral)) > 1e-9 || c.Team2.Climb != -100 || c.Team2.Rest != 7 {
+		t.Errorf("unexpected away conditions for 102: %+v", c.Team2)
+	}
+
+	c = conditions["105"]
+	if c.Team1.Rest != maxRestDays || c.Team2.Rest != maxRestDays {
+		t.Errorf("expected rest to be capped for 105, got %+v", c)
+	}
+	c = conditions["104"]
+	if c.Team1.Rest != 14 || c.Team2.Rest != 7 {
+		t.Errorf("expected two weeks of rest for Alpha and one for Gamma in 104, got %+v", c)
+	}
+}
+
+func TestFitAdjustment(t *testing.T) {
+	teams := make(map[string]*Team)
+	ratings := make(map[*Team]float64)
+	for _, n := range []string{"A", "B", "C"} {
+		teams[n] = fakeTeam(n)
+		ratings[teams[n]] = 0
+	}
+	m := NewGaussianSpreadModel(ratings, 10, 3, 0)
+	want := Adjustment{PerMile: .01, PerRestDay: .5}
+
+	start := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	games := make(map[string]*Game)
+	conditions := make(map[string]Conditions)
+	for i := 0; i < 12; i++ {
+		id := fmt.Sprintf("%02d", i)
+		c := Conditions{
+			Team1: TeamConditions{Travel: float64(100 * (i % 3)), Rest: float64(7 - i%2)},
+			Team2: TeamConditions{Travel: float64(100 * (i % 4)), Rest: float64(5 + i%3)},
+		}
+		margin := int(math.Round(3 + want.Points(c)))
+		games[id] = completedGame("A", "B", 30+margin, 30, false, start.AddDate(0, 0, i))
+		conditions[id] = c
+	}
+	games["unconditioned"] = completedGame("A", "C", 0, 50, false, start)
+
+	got, err := FitAdjustment(m, games, teams, nil, conditions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(got.PerMile-want.PerMile) > 1e-3 || got.PerClimb != 0 || math.Abs(got.PerRestDay-want.PerRestDay) > .05 {
+		t.Errorf("FitAdjustment() = %+v, want %+v", got, want)
+	}
+
+	if _, err := FitAdjustment(m, games, teams, nil, nil); err == nil {
+		t.Errorf("expected error with no conditions")
+	}
+}
+
+func TestFitAdjustment_Location(t *testing.T) {
+	teams := map[string]*Team{"A": fakeTeam("A"), "B": fakeTeam("B")}
+	teams["A"].HomeVenue = detachedRef(VenuesCollection, "a")
+	teams["B"].HomeVenue = detachedRef(VenuesCollection, "b")
+	venues := map[string]*Venue{
+		"a":    {Name: "A Field", LatLonAlt: []float64{40, -100}},
+		"b":    {Name: "B Field", LatLonAlt: []float64{40, -80}},
+		"near": {Name: "Near A", LatLonAlt: []float64{40, -98}},
+	}
+	m := NewGaussianSpreadModel(map[*Team]float64{teams["A"]: 0, teams["B"]: 0}, 10, 3, 2)
+	want := Adjustment{PerMile: .01}
+
+	// Every game is at a neutral site near A, which the close bias explains, so travel explains only the rest of the margin.
+	start := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	games := make(map[string]*Game)
+	conditions := make(map[string]Conditions)
+	for i := 0; i < 6; i++ {
+		id := fmt.Sprintf("%02d", i)
+		c := Conditions{Team2: TeamConditions{Travel: float64(100 * (i + 1))}}
+		g := completedGame("A", "B", 30+2+int(want.Points(c)), 30, true, start.AddDate(0, 0, i))
+		g.Venue = detachedRef(VenuesCollection, "near")
+		games[id] = g
+		conditions[id] = c
+	}
+
+	got, err := FitAdjustment(m, games, teams, venues, conditions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(got.PerMile-want.PerMile) > 1e-9 {
+		t.Errorf("FitAdjustment() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+package pickem
+
+import (
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltStore implements Store using an embedded BoltDB file, so Pick 'Em data can be used without a connection to Firestore.
+// Each collection is stored in a bucket of JSON-encoded documents keyed by document ID.
+type BoltStore struct {
+	docStore
+	db *bolt.DB
+}
+
+// OpenBoltStore opens (or creates) a BoltDB file at the given path as a Store.
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &BoltStore{docStore: docStore{boltBackend{db}}, db: db}, nil
+}
+
+// Close implements Store and closes the BoltDB file.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// boltBackend implements docBackend with one bucket per collection.
+type boltBackend struct {
+	db *bolt.DB
+}
+
+func (b boltBackend) get(collection, id string) ([]byte, error) {
+	var data []byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(collection))
+		if bucket == nil {
+			return fmt.Errorf("%s/%s: %w", collection, id, ErrNotFound)
+		}
+		v := bucket.Get([]byte(id))
+		if v == nil {
+			return fmt.Errorf("%s/%s: %w", collection, id, ErrNotFound)
+		}
+		// v is only valid for the life of the transaction.
+		data = make([]byte, len(v))
+		copy(data, v)
+		return nil
+	})
+	return data, err
+}
+
+func (b boltBackend) each(collection string, f func(id string, data []byte) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(collection))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			return f(string(k), v)
+		})
+	})
+}
+
+func (b boltBackend) put(collection string, docs map[string][]byte, overwrite bool) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(collection))
+		if err != nil {
+			return err
+		}
+		for id, data := range docs {
+			if !overwrite && bucket.Get([]byte(id)) != nil {
+				return fmt.Errorf("%s/%s: %w", collection, id, ErrExists)
+			}
+			if err := bucket.Put([]byte(id), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (b boltBackend) update(collection string, ids []string, f func(id string, data []byte) ([]byte, error)) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(collection))
+		for _, id := range ids {
+			var v []byte
+			if bucket != nil {
+				v = bucket.Get([]byte(id))
+			}
+			if v == nil {
+				return fmt.Errorf("%s/%s: %w", collection, id, ErrNotFound)
+			}
+			data, err := f(id, v)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put([]byte(id), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
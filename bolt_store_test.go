@@ -0,0 +1,101 @@
+package pickem
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cloud.google.com/go/firestore"
+)
+
+func TestBoltStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pickem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := OpenBoltStore(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	ctx := context.Background()
+
+	if _, err := s.Team(ctx, "Alpha"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	teams := map[string]*Team{
+		"Alpha": {SchoolName: "Alpha", Abbreviation: "ALP", Names: []string{"Alpha", "Alph"}, HomeVenue: s.Ref(VenuesCollection, "1")},
+		"Beta":  {SchoolName: "Beta", Abbreviation: "BET", Names: []string{"Beta"}},
+	}
+	if err := s.PutTeams(ctx, teams, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutTeams(ctx, teams, false); !errors.Is(err, ErrExists) {
+		t.Errorf("expected ErrExists, got %v", err)
+	}
+	if err := s.PutTeams(ctx, teams, true); err != nil {
+		t.Errorf("expected overwrite to succeed, got %v", err)
+	}
+
+	team, err := s.Team(ctx, "Alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if team.Abbreviation != "ALP" || team.HomeVenue == nil || team.HomeVenue.ID != "1" {
+		t.Errorf("expected %v, got %v", teams["Alpha"], team)
+	}
+
+	if err := s.SetHomeVenues(ctx, map[string]*firestore.DocumentRef{"Beta": s.Ref(VenuesCollection, "2")}); err != nil {
+		t.Fatal(err)
+	}
+	if team, err := s.Team(ctx, "Beta"); err != nil || team.HomeVenue == nil || team.HomeVenue.ID != "2" || team.Abbreviation != "BET" {
+		t.Errorf("expected only the home venue of Beta to change, got %v (%v)", team, err)
+	}
+	if err := s.SetHomeVenues(ctx, map[string]*firestore.DocumentRef{"Omega": s.Ref(VenuesCollection, "2")}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	byName, err := s.TeamsByName(ctx, "Alph")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byName) != 1 || byName["Alpha"] == nil {
+		t.Errorf("expected only Alpha, got %v", byName)
+	}
+
+	if err := s.PutSeasons(ctx, map[string]*Season{"2019": {Year: 2019}}, false); err != nil {
+		t.Fatal(err)
+	}
+	if season, err := s.Season(ctx, SeasonRef(s, 2019).ID); err != nil || season.Year != 2019 {
+		t.Errorf("expected season 2019, got %v (%v)", season, err)
+	}
+	if _, err := s.Season(ctx, "2018"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	games := map[string]*Game{
+		"1": {Season: SeasonRef(s, 2019), Week: 1, HomeTeam: s.Ref(TeamsCollection, "Alpha"), AwayTeam: s.Ref(TeamsCollection, "Beta")},
+		"2": {Season: SeasonRef(s, 2019), Week: 2, HomeTeam: s.Ref(TeamsCollection, "Beta"), AwayTeam: s.Ref(TeamsCollection, "Alpha")},
+		"3": {Season: SeasonRef(s, 2018), Week: 2, HomeTeam: s.Ref(TeamsCollection, "Beta"), AwayTeam: s.Ref(TeamsCollection, "Alpha")},
+	}
+	if err := s.PutGames(ctx, games, false); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Games(ctx, GameQuery{Season: 2019, MinWeek: 2, Team: "Alpha"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got["2"] == nil {
+		t.Errorf("expected only game 2, got %v", got)
+	}
+	if got["2"].Timestamp.IsZero() {
+		t.Errorf("expected timestamp to be set")
+	}
+}
@@ -0,0 +1,184 @@
+package pickem
+
+import (
+	"fmt"
+	"math"
+)
+
+// Calibration holds the parameters shared by GaussianSpreadModel and LookupModel that turn a predicted spread into a probability of win.
+type Calibration struct {
+	StdDev    float64
+	HomeBias  float64
+	CloseBias float64
+}
+
+// A SpreadObservation is the outcome of a past Matchup along with the spread that was predicted for its first team, before any
+// home or close bias was added.
+type SpreadObservation struct {
+	Matchup Matchup
+	Spread  float64
+	// Won is true if the first team won.
+	Won bool
+}
+
+// biasFeatures returns the signs of the home and close biases for the first team of a Matchup played at the given location.
+func biasFeatures(loc RelativeLocation) (home, close float64) {
+	switch loc {
+	case Home:
+		return 1, 0
+	case Away:
+		return -1, 0
+	case Near:
+		return 0, 1
+	case Far:
+		return 0, -1
+	}
+	return 0, 0
+}
+
+// normalCdf and normalPdf are the standard normal distribution functions, written in terms of math.Erfc for accuracy in the tails.
+func normalCdf(z float64) float64 { return .5 * math.Erfc(-z/math.Sqrt2) }
+func normalPdf(z float64) float64 { return math.Exp(-z*z/2) / math.Sqrt(2*math.Pi) }
+
+// probitLogLikelihood returns the log-likelihood of the outcomes given coefficients on the spread, home, and close features.
+func probitLogLikelihood(x [][3]float64, won []bool, beta [3]float64) float64 {
+	ll := 0.
+	for i, xi := range x {
+		eta := beta[0]*xi[0] + beta[1]*xi[1] + beta[2]*xi[2]
+		if !won[i] {
+			eta = -eta
+		}
+		ll += math.Log(normalCdf(eta))
+	}
+	return ll
+}
+
+// calibrationIterations is the maximum number of Newton steps taken by Calibrate.
+const calibrationIterations = 100
+
+// Calibrate finds the standard deviation, home bias, and close bias that maximize the likelihood of the observed outcomes,
+// where the first team of each Matchup wins with probability given by the Normal CDF of its biased spread.
+//
+// The fit is a probit regression of the outcomes on the spread and the location of each Matchup, solved by Newton's method.
+// A bias is fixed at zero if no observation is played at a location that uses it.  An error is returned if the outcomes are
+// perfectly predicted by the spreads, in which case the standard deviation shrinks without bound.
+func Calibrate(obs []SpreadObservation) (Calibration, error) {
+	if len(obs) == 0 {
+		return Calibration{}, fmt.Errorf("no observations to calibrate")
+	}
+	x := make([][3]float64, len(obs))
+	won := make([]bool, len(obs))
+	var used [3]bool
+	used[0] = true
+	for i, o := range obs {
+		h, c := biasFeatures(o.Matchup.Location)
+		x[i] = [3]float64{o.Spread, h, c}
+		won[i] = o.Won
+		used[1] = used[1] || h != 0
+		used[2] = used[2] || c != 0
+	}
+
+	// Coefficients are 1/stdDev, homeBias/stdDev, and closeBias/stdDev, starting from a standard deviation of 10 points.
+	beta := [3]float64{.1, 0, 0}
+	ll := probitLogLikelihood(x, won, beta)
+	converged := false
+	for iter := 0; iter < calibrationIterations && !converged; iter++ {
+		grad := make([]float64, 3)
+		hess := make([][]float64, 3)
+		for j := range hess {
+			hess[j] = make([]float64, 3)
+		}
+		for i, xi := range x {
+			q := 1.
+			if !won[i] {
+				q = -1.
+			}
+			eta := q * (beta[0]*xi[0] + beta[1]*xi[1] + beta[2]*xi[2])
+			lambda := normalPdf(eta) / normalCdf(eta)
+			w := lambda * (lambda + eta)
+			for j := 0; j < 3; j++ {
+				grad[j] += q * lambda * xi[j]
+				for k := 0; k < 3; k++ {
+					hess[j][k] += w * xi[j] * xi[k]
+				}
+			}
+		}
+		for j := range used {
+			if !used[j] {
+				hess[j][j] = 1
+			}
+		}
+		step, err := solveLinear(hess, grad)
+		if err != nil {
+			return Calibration{}, fmt.Errorf("cannot calibrate: %v", err)
+		}
+
+		// Halve the step until the likelihood does not decrease.
+		for scale := 1.; scale > 1e-10; scale /= 2 {
+			var next [3]float64
+			converged = true
+			for j := range next {
+				next[j] = beta[j] + scale*step[j]
+				converged = converged && math.Abs(scale*step[j]) < 1e-10*(1+math.Abs(beta[j]))
+			}
+			if nextLL := probitLogLikelihood(x, won, next); nextLL >= ll {
+				beta, ll = next, nextLL
+				break
+			}
+			converged = false
+		}
+	}
+
+	if !converged || beta[0] <= 0 {
+		return Calibration{}, fmt.Errorf("calibration did not converge: outcomes may be perfectly predicted by spreads")
+	}
+	return Calibration{StdDev: 1 / beta[0], HomeBias: beta[1] / beta[0], CloseBias: beta[2] / beta[0]}, nil
+}
+
+// spreadObservations makes a SpreadObservation from each completed game that did not end in a tie, with the home team first.
+// Games at a neutral site are classified as Near, Far, or Neutral relative to the home team by the teams' home venues, as
+// GameLocation does.  Neutral sites whose venues are not in the venues map are Neutral.
+func spreadObservations(games map[string]*Game, teams map[string]*Team, venues map[string]*Venue, spread func(t1, t2 *Team) (float64, error)) ([]SpreadObservation, error) {
+	completed := completedGames(games)
+	obs := make([]SpreadObservation, 0, len(completed))
+	for _, g := range completed {
+		if g.margin() == 0 {
+			continue
+		}
+		mu, err := gameMatchup(g, teams, venues)
+		if err != nil {
+			return nil, err
+		}
+		s, err := spread(mu.Team1, mu.Team2)
+		if err != nil {
+			return nil, err
+		}
+		obs = append(obs, SpreadObservation{Matchup: mu, Spread: s, Won: g.margin() > 0})
+	}
+	return obs, nil
+}
+
+// Observations pairs the model's unbiased spreads with the outcomes of the completed Games, for use with Calibrate.
+// The teams and venues maps look up the Teams and Venues referenced by the Games by document ID, as returned by Store.Teams
+// and Store.Venues.  The venues are used to classify neutral sites as Near or Far, and may be nil.
+func (m *GaussianSpreadModel) Observations(games map[string]*Game, teams map[string]*Team, venues map[string]*Venue) ([]SpreadObservation, error) {
+	return spreadObservations(games, teams, venues, func(t1, t2 *Team) (float64, error) {
+		return m.baseSpread(t1, t2, Neutral)
+	})
+}
+
+// Observations pairs the model's unbiased spreads with the outcomes of the completed Games, for use with Calibrate.
+// The teams and venues maps look up the Teams and Venues referenced by the Games by document ID, as returned by Store.Teams
+// and Store.Venues.  The venues are used to classify neutral sites as Near or Far, and may be nil.
+func (m *LookupModel) Observations(games map[string]*Game, teams map[string]*Team, venues map[string]*Venue) ([]SpreadObservation, error) {
+	return spreadObservations(games, teams, venues, func(t1, t2 *Team) (float64, error) {
+		spread, swap, ok := m.spreads.get(t1, t2)
+		if !ok {
+			return 0., fmt.Errorf("spread between teams %s and %s not found", t1.Name(), t2.Name())
+		}
+		if swap {
+			spread = -spread
+		}
+		return spread, nil
+	})
+}
@@ -0,0 +1,187 @@
+package pickem
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestCalibrate(t *testing.T) {
+	want := Calibration{StdDev: 12, HomeBias: 3, CloseBias: 1.5}
+	locs := []RelativeLocation{Home, Near, Neutral, Far, Away}
+	rng := rand.New(rand.NewSource(42))
+	obs := make([]SpreadObservation, 50000)
+	for i := range obs {
+		loc := locs[rng.Intn(len(locs))]
+		h, c := biasFeatures(loc)
+		spread := rng.Float64()*40 - 20
+		margin := spread + h*want.HomeBias + c*want.CloseBias + rng.NormFloat64()*want.StdDev
+		obs[i] = SpreadObservation{Matchup: Matchup{Location: loc}, Spread: spread, Won: margin > 0}
+	}
+
+	got, err := Calibrate(obs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(got.StdDev-want.StdDev) > 1 {
+		t.Errorf("StdDev = %v, want %v", got.StdDev, want.StdDev)
+	}
+	if math.Abs(got.HomeBias-want.HomeBias) > .5 {
+		t.Errorf("HomeBias = %v, want %v", got.HomeBias, want.HomeBias)
+	}
+	if math.Abs(got.CloseBias-want.CloseBias) > .5 {
+		t.Errorf("CloseBias = %v, want %v", got.CloseBias, want.CloseBias)
+	}
+
+	// Without Near or Far games, the close bias is fixed at zero.
+	neutral := make([]SpreadObservation, 0, len(obs))
+	for _, o := range obs {
+		if o.Matchup.Location != Near && o.Matchup.Location != Far {
+			neutral = append(neutral, o)
+		}
+	}
+	got, err = Calibrate(neutral)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.CloseBias != 0 {
+		t.Errorf("CloseBias = %v, want 0", got.CloseBias)
+	}
+}
+
+func TestCalibrate_Errors(t *testing.T) {
+	if _, err := Calibrate(nil); err == nil {
+		t.Errorf("expected error for no observations")
+	}
+	separable := []SpreadObservation{
+		{Spread: 3, Won: true},
+		{Spread: 1, Won: true},
+		{Spread: -2, Won: false},
+	}
+	if _, err := Calibrate(separable); err == nil {
+		t.Errorf("expected error for perfectly predicted outcomes")
+	}
+}
+
+func TestObservations(t *testing.T) {
+	teams := map[string]*Team{"A": fakeTeam("A"), "B": fakeTeam("B"), "C": fakeTeam("C")}
+	for id, team := range teams {
+		team.SchoolName = id
+	}
+	start := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	games := map[string]*Game{
+		"1": completedGame("A", "B", 21, 14, false, start),
+		"2": completedGame("C", "A", 10, 17, true, start.Add(time.Hour)),
+		"3": completedGame("B", "C", 7, 7, false, start.Add(2*time.Hour)),
+		"4": {HomeTeam: detachedRef(TeamsCollection, "B"), AwayTeam: detachedRef(TeamsCollection, "A")},
+	}
+
+	gsm := NewGaussianSpreadModel(map[*Team]float64{teams["A"]: 5, teams["B"]: 1, teams["C"]: -2}, 10, 3, 1)
+	lm := NewLookupModel([]*Team{teams["A"], teams["A"]}, []*Team{teams["B"], teams["C"]}, []float64{4, 7}, 10, 3, 1)
+	want := []SpreadObservation{
+		{Matchup: Matchup{Team1: teams["A"], Team2: teams["B"], Location: Home}, Spread: 4, Won: true},
+		{Matchup: Matchup{Team1: teams["C"], Team2: teams["A"], Location: Neutral}, Spread: -7, Won: false},
+	}
+
+	for name, model := range map[string]interface {
+		Observations(map[string]*Game, map[string]*Team, map[string]*Venue) ([]SpreadObservation, error)
+	}{"gaussian": gsm, "lookup": lm} {
+		got, err := model.Observations(games, teams, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s: expected %d observations, got %d", name, len(want), len(got))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: observation %d = %+v, want %+v", name, i, got[i], want[i])
+			}
+		}
+	}
+
+	games["5"] = completedGame("B", "Z", 1, 0, false, start)
+	if _, err := gsm.Observations(games, teams, nil); err == nil {
+		t.Errorf("expected error for unknown team")
+	}
+}
+
+func TestObservations_CloseBias(t *testing.T) {
+	want := Calibration{StdDev: 12, HomeBias: 3, CloseBias: 2}
+	venues := map[string]*Venue{
+		"west":      {Name: "West", LatLonAlt: []float64{40, -100}},
+		"east":      {Name: "East", LatLonAlt: []float64{40, -80}},
+		"near west": {Name: "Near West", LatLonAlt: []float64{40, -98}},
+		"near east": {Name: "Near East", LatLonAlt: []float64{40, -82}},
+	}
+	teams := make(map[string]*Team)
+	ratings := make(map[*Team]float64)
+	ids := make([]string, 10)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("T%d", i)
+		team := fakeTeam(ids[i])
+		team.SchoolName = ids[i]
+		team.HomeVenue = detachedRef(VenuesCollection, "west")
+		if i%2 == 1 {
+			team.HomeVenue = detachedRef(VenuesCollection, "east")
+		}
+		teams[ids[i]] = team
+		ratings[team] = float64(2*i - 9)
+	}
+	model := NewGaussianSpreadModel(ratings, want.StdDev, want.HomeBias, want.CloseBias)
+
+	rng := rand.New(rand.NewSource(42))
+	start := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	games := make(map[string]*Game)
+	for i := 0; i < 40000; i++ {
+		// Pair a western team with an eastern team so neutral sites are near one of them.
+		home, away := ids[2*rng.Intn(5)], ids[2*rng.Intn(5)+1]
+		if rng.Intn(2) == 0 {
+			home, away = away, home
+		}
+		site := []string{"", "near west", "near east"}[rng.Intn(3)]
+		loc := Home
+		if site != "" {
+			loc = Far
+			if teams[home].HomeVenue.ID == map[string]string{"near west": "west", "near east": "east"}[site] {
+				loc = Near
+			}
+		}
+		_, spread, err := model.Predict(Matchup{Team1: teams[home], Team2: teams[away], Location: loc})
+		if err != nil {
+			t.Fatal(err)
+		}
+		margin := int(math.Round(spread + rng.NormFloat64()*want.StdDev))
+		g := completedGame(home, away, 100+margin, 100, site != "", start.Add(time.Duration(i)*time.Minute))
+		if site != "" {
+			g.Venue = detachedRef(VenuesCollection, site)
+		}
+		games[fmt.Sprintf("%05d", i)] = g
+	}
+
+	obs, err := model.Observations(games, teams, venues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	near := 0
+	for _, o := range obs {
+		if o.Matchup.Location == Near || o.Matchup.Location == Far {
+			near++
+		}
+	}
+	if near == 0 {
+		t.Fatalf("expected neutral sites to be classified as Near or Far")
+	}
+	got, err := Calibrate(obs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(got.CloseBias-want.CloseBias) > .75 {
+		t.Errorf("CloseBias = %v, want %v", got.CloseBias, want.CloseBias)
+	}
+	if math.Abs(got.HomeBias-want.HomeBias) > .75 {
+		t.Errorf("HomeBias = %v, want %v", got.HomeBias, want.HomeBias)
+	}
+}
@@ -14,7 +14,6 @@ import (
 
 	"cloud.google.com/go/firestore"
 	"github.com/reallyasi9/pickem"
-	"google.golang.org/api/iterator"
 )
 
 var gamesFlagSet flag.FlagSet
@@ -59,8 +58,8 @@ func init() {
 	gamesFlagSet.StringVar(&gamesTeamFlag, "team", "", "team download filter")
 	gamesFlagSet.Var(&gamesSeasonTypeFlag, "type", "season type download filter (regular or postseason)")
 	gamesFlagSet.BoolVar(&gamesUpdateTeamVenueFlag, "updateVenues", false, "update home team venues using game information")
-	gamesFlagSet.BoolVar(&dryRunFlag, "dryrun", false, "download and print actions only (do not upload to Firestore)")
-	gamesFlagSet.BoolVar(&overwriteFlag, "overwrite", false, "overwrite documents in Firestore if they already exist")
+	gamesFlagSet.BoolVar(&dryRunFlag, "dryrun", false, "download and print actions only (do not write to the store)")
+	gamesFlagSet.BoolVar(&overwriteFlag, "overwrite", false, "overwrite documents in the store if they already exist")
 }
 
 type cfbdGame struct {
@@ -87,14 +86,14 @@ type cfbdGame struct {
 func (g cfbdGame) pickem() (*pickem.Game, error) {
 	var pg pickem.Game
 
-	pg.Season = fs.Collection("seasons").Doc(strconv.Itoa(g.Season))
+	pg.Season = pickem.SeasonRef(store, g.Season)
 	pg.Week = g.Week
 	pg.Postseason = g.SeasonType == postseason
 	pg.StartTime = g.StartDate
 	pg.NeutralSite = g.NeutralSite
 	pg.ConferenceGame = g.ConferenceGame
 	pg.Attendance = g.Attendance
-	pg.Venue = fs.Collection("xvenues").Doc(strconv.Itoa(g.VenueID))
+	pg.Venue = store.Ref(pickem.VenuesCollection, strconv.Itoa(g.VenueID))
 	var ok bool
 	if pg.HomeTeam, ok = bySchool[g.HomeTeam]; !ok {
 		return nil, fmt.Errorf("ID of team '%s' not found", g.HomeTeam)
@@ -114,24 +113,16 @@ var bySchool map[string]*firestore.DocumentRef
 
 func fillSchools(ctx context.Context) error {
 	bySchool = make(map[string]*firestore.DocumentRef)
-	itr := fs.Collection("xteams").Documents(ctx)
-	defer itr.Stop()
-	for {
-		doc, err := itr.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return err
-		}
-		school, err := doc.DataAt("school_name")
-		if err != nil {
-			return err
-		}
-		if _, ok := bySchool[school.(string)]; ok {
-			return fmt.Errorf("school '%s' appears in data more than once", school.(string))
+	teams, err := store.Teams(ctx)
+	if err != nil {
+		return err
+	}
+	for id, team := range teams {
+		school := team.SchoolName
+		if _, ok := bySchool[school]; ok {
+			return fmt.Errorf("school '%s' appears in data more than once", school)
 		}
-		bySchool[school.(string)] = doc.Ref
+		bySchool[school] = store.Ref(pickem.TeamsCollection, id)
 	}
 	return nil
 }
@@ -184,8 +175,7 @@ func games(ctx context.Context, args []string) error {
 		return err
 	}
 
-	toWrite := newFSCommitter(fs, 250)
-	collection := fs.Collection("xgames")
+	toWrite := make(map[string]*pickem.Game)
 	byHomeTeam := make(map[string]*firestore.DocumentRef)
 
 	var g cfbdGame
@@ -194,7 +184,7 @@ func games(ctx context.Context, args []string) error {
 		if err != nil {
 			return err
 		}
-		ref := collection.Doc(strconv.Itoa(g.ID))
+		id := strconv.Itoa(g.ID)
 
 		if gamesUpdateTeamVenueFlag && !g.NeutralSite {
 			if v, ok := byHomeTeam[game.HomeTeam.ID]; ok && v.ID != game.Venue.ID {
@@ -204,53 +194,24 @@ func games(ctx context.Context, args []string) error {
 		}
 
 		if dryRunFlag {
-			fmt.Printf("%s <- %v\n", ref.ID, game)
+			fmt.Printf("%s <- %v\n", id, game)
 			continue
 		}
-
-		if overwriteFlag {
-			if err := toWrite.Set(ctx, ref, &game); err != nil {
-				return err
-			}
-		} else {
-			if err := toWrite.Create(ctx, ref, &game); err != nil {
-				return err
-			}
-		}
+		toWrite[id] = game
 	}
 
-	if err := toWrite.Commit(ctx); err != nil {
-		return err
+	if !dryRunFlag {
+		if err := store.PutGames(ctx, toWrite, overwriteFlag); err != nil {
+			return err
+		}
 	}
 
 	if gamesUpdateTeamVenueFlag {
-		itr := fs.Collection("xteams").Documents(ctx)
-		defer itr.Stop()
-		for {
-			doc, err := itr.Next()
-			if err == iterator.Done {
-				break
-			}
-			if err != nil {
-				return err
-			}
-			var venue *firestore.DocumentRef
-			var ok bool
-			if venue, ok = byHomeTeam[doc.Ref.ID]; !ok {
-				continue
-			}
-			if dryRunFlag {
-				fmt.Printf("%s <- %s\n", doc.Ref.ID, venue.ID)
-				continue
-			}
-			if err := toWrite.Update(ctx, doc.Ref, []firestore.Update{{Path: "home_venue", Value: venue}}); err != nil {
-				return err
+		if dryRunFlag {
+			for id, venue := range byHomeTeam {
+				fmt.Printf("%s <- %s\n", id, venue.ID)
 			}
-		}
-	}
-
-	if !dryRunFlag {
-		if err := toWrite.Commit(ctx); err != nil {
+		} else if err := store.SetHomeVenues(ctx, byHomeTeam); err != nil {
 			return err
 		}
 	}
@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"cloud.google.com/go/firestore"
+	"github.com/reallyasi9/pickem"
 )
 
 var commands map[string]func(context.Context, []string) error = make(map[string]func(context.Context, []string) error)
@@ -16,7 +17,7 @@ const apiURL = "https://api.collegefootballdata.com"
 
 var client http.Client
 
-var fs *firestore.Client
+var store pickem.Store
 
 func printUsage() {
 	fmt.Println("Usage: download CMD [options...]")
@@ -34,11 +35,18 @@ func init() {
 	client = http.Client{
 		// default
 	}
-	var err error
-	fs, err = firestore.NewClient(context.Background(), os.Getenv("GCP_PROJECT"))
+}
+
+// openStore opens the BoltDB file named by PICKEM_DB if set, otherwise the Firestore database of GCP_PROJECT.
+func openStore(ctx context.Context) (pickem.Store, error) {
+	if path := os.Getenv("PICKEM_DB"); path != "" {
+		return pickem.OpenBoltStore(path)
+	}
+	fs, err := firestore.NewClient(ctx, os.Getenv("GCP_PROJECT"))
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
+	return pickem.NewFirestoreStore(fs), nil
 }
 
 func main() {
@@ -50,7 +58,14 @@ func main() {
 	}
 	cmd, args := args[0], args[1:]
 	if f, ok := commands[cmd]; ok {
-		err := f(ctx, args)
+		var err error
+		if store, err = openStore(ctx); err != nil {
+			log.Fatal(err)
+		}
+		err = f(ctx, args)
+		if cerr := store.Close(); err == nil {
+			err = cerr
+		}
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -27,7 +27,7 @@ func schedules(ctx context.Context, args []string) error {
 		return err
 	}
 
-	matches, err := pickem.Matchups(ctx, fs, schedulesTeamFlag, schedulesYearFlag, schedulesWeekFlag)
+	matches, err := pickem.Matchups(ctx, store, schedulesTeamFlag, schedulesYearFlag, schedulesWeekFlag)
 	if err != nil {
 		return err
 	}
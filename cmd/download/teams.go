@@ -20,8 +20,8 @@ func init() {
 	commands["teams"] = teams
 
 	teamsFlagSet.StringVar(&teamsConferenceFlag, "conference", "", "conference download filter")
-	teamsFlagSet.BoolVar(&dryRunFlag, "dryrun", false, "download and print actions only (do not upload to Firestore)")
-	teamsFlagSet.BoolVar(&overwriteFlag, "overwrite", false, "overwrite documents in Firestore if they already exist")
+	teamsFlagSet.BoolVar(&dryRunFlag, "dryrun", false, "download and print actions only (do not write to the store)")
+	teamsFlagSet.BoolVar(&overwriteFlag, "overwrite", false, "overwrite documents in the store if they already exist")
 }
 
 type cfbdTeam struct {
@@ -128,8 +128,7 @@ func teams(ctx context.Context, args []string) error {
 		return err
 	}
 
-	toWrite := newFSCommitter(fs, 500)
-	collection := fs.Collection("xteams")
+	toWrite := make(map[string]*pickem.Team)
 
 	var t cfbdTeam
 	for _, t = range teams {
@@ -137,25 +136,15 @@ func teams(ctx context.Context, args []string) error {
 		if err != nil {
 			return err
 		}
-		ref := collection.Doc(t.School)
 		if dryRunFlag {
-			fmt.Printf("%s <- %v\n", ref.ID, team)
+			fmt.Printf("%s <- %v\n", t.School, team)
 			continue
 		}
-
-		if overwriteFlag {
-			if err := toWrite.Set(ctx, ref, &team); err != nil {
-				return err
-			}
-		} else {
-			if err := toWrite.Create(ctx, ref, &team); err != nil {
-				return err
-			}
-		}
+		toWrite[t.School] = team
 	}
 
 	if !dryRunFlag {
-		if err := toWrite.Commit(ctx); err != nil {
+		if err := store.PutTeams(ctx, toWrite, overwriteFlag); err != nil {
 			return err
 		}
 	}
@@ -19,8 +19,8 @@ var venuesFlagSet flag.FlagSet
 func init() {
 	commands["venues"] = venues
 
-	venuesFlagSet.BoolVar(&dryRunFlag, "dryrun", false, "download and print actions only (do not upload to Firestore)")
-	venuesFlagSet.BoolVar(&overwriteFlag, "overwrite", false, "overwrite documents in Firestore if they already exist")
+	venuesFlagSet.BoolVar(&dryRunFlag, "dryrun", false, "download and print actions only (do not write to the store)")
+	venuesFlagSet.BoolVar(&overwriteFlag, "overwrite", false, "overwrite documents in the store if they already exist")
 }
 
 type cfbdVenue struct {
@@ -109,8 +109,7 @@ func venues(ctx context.Context, args []string) error {
 		return err
 	}
 
-	toWrite := newFSCommitter(fs, 500)
-	collection := fs.Collection("xvenues")
+	toWrite := make(map[string]*pickem.Venue)
 
 	var v cfbdVenue
 	for _, v = range venues {
@@ -118,25 +117,16 @@ func venues(ctx context.Context, args []string) error {
 		if err != nil {
 			return err
 		}
-		ref := collection.Doc(strconv.Itoa(v.ID))
+		id := strconv.Itoa(v.ID)
 		if dryRunFlag {
-			fmt.Printf("%s <- %v\n", ref.ID, venue)
+			fmt.Printf("%s <- %v\n", id, venue)
 			continue
 		}
-
-		if overwriteFlag {
-			if err := toWrite.Set(ctx, ref, &venue); err != nil {
-				return err
-			}
-		} else {
-			if err := toWrite.Create(ctx, ref, &venue); err != nil {
-				return err
-			}
-		}
+		toWrite[id] = venue
 	}
 
 	if !dryRunFlag {
-		if err := toWrite.Commit(ctx); err != nil {
+		if err := store.PutVenues(ctx, toWrite, overwriteFlag); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,266 @@
+package pickem
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// A docBackend stores encoded documents by collection and ID.
+type docBackend interface {
+	// get returns the document with the given ID, or an error wrapping ErrNotFound.
+	get(collection, id string) ([]byte, error)
+	// each calls f with every document in the collection.
+	each(collection string, f func(id string, data []byte) error) error
+	// put writes the documents atomically, returning an error wrapping ErrExists if overwrite is false and any document exists.
+	put(collection string, docs map[string][]byte, overwrite bool) error
+	// update replaces each document with the result of f atomically, returning an error wrapping ErrNotFound if any document
+	// does not exist.
+	update(collection string, ids []string, f func(id string, data []byte) ([]byte, error)) error
+}
+
+// docStore implements the document methods of Store on top of a docBackend by encoding documents as JSON.
+// References are stored detached from any Firestore client.
+type docStore struct {
+	b docBackend
+}
+
+// Ref implements Store.
+func (s docStore) Ref(collection, id string) *firestore.DocumentRef {
+	return detachedRef(collection, id)
+}
+
+func (s docStore) get(collection, id string, p interface{}) error {
+	data, err := s.b.get(collection, id)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, p)
+}
+
+func (s docStore) put(collection string, docs map[string]interface{}, overwrite bool) error {
+	encoded := make(map[string][]byte)
+	for id, doc := range docs {
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		encoded[id] = data
+	}
+	return s.b.put(collection, encoded, overwrite)
+}
+
+// teams returns the teams for which keep returns true.
+func (s docStore) teams(keep func(*Team) bool) (map[string]*Team, error) {
+	teams := make(map[string]*Team)
+	err := s.b.each(TeamsCollection, func(id string, data []byte) error {
+		var t Team
+		if err := json.Unmarshal(data, &t); err != nil {
+			return err
+		}
+		if keep(&t) {
+			teams[id] = t.mapRefs(detach)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// Team implements Store.
+func (s docStore) Team(ctx context.Context, id string) (*Team, error) {
+	var t Team
+	if err := s.get(TeamsCollection, id, &t); err != nil {
+		return nil, err
+	}
+	return t.mapRefs(detach), nil
+}
+
+// Teams implements Store.
+func (s docStore) Teams(ctx context.Context) (map[string]*Team, error) {
+	return s.teams(func(*Team) bool { return true })
+}
+
+// TeamsByAbbreviation implements Store.
+func (s docStore) TeamsByAbbreviation(ctx context.Context, abbreviation string) (map[string]*Team, error) {
+	return s.teams(func(t *Team) bool { return t.Abbreviation == abbreviation })
+}
+
+// TeamsByName implements Store.
+func (s docStore) TeamsByName(ctx context.Context, name string) (map[string]*Team, error) {
+	return s.teams(func(t *Team) bool {
+		for _, n := range t.Names {
+			if n == name {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// PutTeams implements Store.
+func (s docStore) PutTeams(ctx context.Context, teams map[string]*Team, overwrite bool) error {
+	docs := make(map[string]interface{})
+	for id, t := range teams {
+		docs[id] = t.mapRefs(detach)
+	}
+	return s.put(TeamsCollection, docs, overwrite)
+}
+
+// SetHomeVenues implements Store.
+func (s docStore) SetHomeVenues(ctx context.Context, venues map[string]*firestore.DocumentRef) error {
+	ids := make([]string, 0, len(venues))
+	for id := range venues {
+		ids = append(ids, id)
+	}
+	return s.b.update(TeamsCollection, ids, func(id string, data []byte) ([]byte, error) {
+		var t Team
+		if err := json.Unmarshal(data, &t); err != nil {
+			return nil, err
+		}
+		t.HomeVenue = venues[id]
+		return json.Marshal(t.mapRefs(detach))
+	})
+}
+
+// Venue implements Store.
+func (s docStore) Venue(ctx context.Context, id string) (*Venue, error) {
+	var v Venue
+	if err := s.get(VenuesCollection, id, &v); err != nil {
+		return nil, err
+	}
+	return v.mapRefs(detach), nil
+}
+
+// Venues implements Store.
+func (s docStore) Venues(ctx context.Context) (map[string]*Venue, error) {
+	venues := make(map[string]*Venue)
+	err := s.b.each(VenuesCollection, func(id string, data []byte) error {
+		var v Venue
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		venues[id] = v.mapRefs(detach)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return venues, nil
+}
+
+// PutVenues implements Store.
+func (s docStore) PutVenues(ctx context.Context, venues map[string]*Venue, overwrite bool) error {
+	docs := make(map[string]interface{})
+	for id, v := range venues {
+		docs[id] = v.mapRefs(detach)
+	}
+	return s.put(VenuesCollection, docs, overwrite)
+}
+
+// Games implements Store.
+func (s docStore) Games(ctx context.Context, q GameQuery) (map[string]*Game, error) {
+	games := make(map[string]*Game)
+	err := s.b.each(GamesCollection, func(id string, data []byte) error {
+		var g Game
+		if err := json.Unmarshal(data, &g); err != nil {
+			return err
+		}
+		if q.matches(&g) {
+			games[id] = g.mapRefs(detach)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return games, nil
+}
+
+// PutGames implements Store.  Like Firestore, a zero Timestamp is replaced with the time of the write.
+func (s docStore) PutGames(ctx context.Context, games map[string]*Game, overwrite bool) error {
+	now := time.Now()
+	docs := make(map[string]interface{})
+	for id, g := range games {
+		gd := g.mapRefs(detach)
+		if gd.Timestamp.IsZero() {
+			gd.Timestamp = now
+		}
+		docs[id] = gd
+	}
+	return s.put(GamesCollection, docs, overwrite)
+}
+
+// Season implements Store.
+func (s docStore) Season(ctx context.Context, id string) (*Season, error) {
+	var season Season
+	if err := s.get(SeasonsCollection, id, &season); err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+// Seasons implements Store.
+func (s docStore) Seasons(ctx context.Context) (map[string]*Season, error) {
+	seasons := make(map[string]*Season)
+	err := s.b.each(SeasonsCollection, func(id string, data []byte) error {
+		var season Season
+		if err := json.Unmarshal(data, &season); err != nil {
+			return err
+		}
+		seasons[id] = &season
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return seasons, nil
+}
+
+// PutSeasons implements Store.
+func (s docStore) PutSeasons(ctx context.Context, seasons map[string]*Season, overwrite bool) error {
+	docs := make(map[string]interface{})
+	for id, season := range seasons {
+		docs[id] = season
+	}
+	return s.put(SeasonsCollection, docs, overwrite)
+}
+
+// Player implements Store.
+func (s docStore) Player(ctx context.Context, id string) (*Player, error) {
+	var p Player
+	if err := s.get(PlayersCollection, id, &p); err != nil {
+		return nil, err
+	}
+	return p.mapRefs(detach), nil
+}
+
+// Players implements Store.
+func (s docStore) Players(ctx context.Context) (map[string]*Player, error) {
+	players := make(map[string]*Player)
+	err := s.b.each(PlayersCollection, func(id string, data []byte) error {
+		var p Player
+		if err := json.Unmarshal(data, &p); err != nil {
+			return err
+		}
+		players[id] = p.mapRefs(detach)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return players, nil
+}
+
+// PutPlayers implements Store.
+func (s docStore) PutPlayers(ctx context.Context, players map[string]*Player, overwrite bool) error {
+	docs := make(map[string]interface{})
+	for id, p := range players {
+		docs[id] = p.mapRefs(detach)
+	}
+	return s.put(PlayersCollection, docs, overwrite)
+}
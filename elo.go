@@ -0,0 +1,182 @@
+package pickem
+
+import (
+	"fmt"
+	"math"
+)
+
+// EloOptions are the parameters of an EloModel.
+type EloOptions struct {
+	// Initial is the rating of a team that has not played.
+	Initial float64
+	// K scales the number of rating points exchanged in a game.  The points exchanged are K times the margin-of-victory
+	// multiplier times the difference between the result and the predicted probability of win, so evenly matched teams
+	// exchange K*ln(2)/2 (about 0.35K) points in a game decided by one point and K*ln(8)/2 (about 1.04K) points in a game
+	// decided by seven.
+	K float64
+	// HomeAdvantage is the number of rating points added to the home team.
+	HomeAdvantage float64
+	// CloseAdvantage is the number of rating points added to the team closer to a neutral site.
+	CloseAdvantage float64
+	// Regression is the fraction of the distance to the initial rating that every rating moves between seasons.
+	Regression float64
+	// EloPerPoint is the rating difference that is worth one point of spread.
+	EloPerPoint float64
+}
+
+// DefaultEloOptions are typical parameters for college football.
+var DefaultEloOptions = EloOptions{
+	Initial:        1500,
+	K:              20,
+	HomeAdvantage:  65,
+	CloseAdvantage: 25,
+	Regression:     1. / 3.,
+	EloPerPoint:    25,
+}
+
+// EloModel implements MatchupPredicter with Elo ratings that are updated game by game.
+//
+// The first team of a Matchup wins with probability 1/(1+10^(-d/400)), where d is the difference in ratings plus any home or
+// close advantage, and is predicted to win by d/EloPerPoint points.
+type EloModel struct {
+	opts    EloOptions
+	ratings map[*Team]float64
+}
+
+// validate returns an error if the options cannot be used by an EloModel.
+func (opts EloOptions) validate() error {
+	if opts.K <= 0 {
+		return fmt.Errorf("K must be positive, got %f", opts.K)
+	}
+	if opts.EloPerPoint <= 0 {
+		return fmt.Errorf("EloPerPoint must be positive, got %f", opts.EloPerPoint)
+	}
+	if opts.Regression < 0 || opts.Regression > 1 {
+		return fmt.Errorf("Regression must be between 0 and 1, got %f", opts.Regression)
+	}
+	return nil
+}
+
+// NewEloModel makes a model in which no team has played.  It panics if the options are invalid.
+func NewEloModel(opts EloOptions) *EloModel {
+	if err := opts.validate(); err != nil {
+		panic(err)
+	}
+	return &EloModel{opts: opts, ratings: make(map[*Team]float64)}
+}
+
+// Rating returns the team's current rating.
+func (m *EloModel) Rating(t *Team) float64 {
+	if r, ok := m.ratings[t]; ok {
+		return r
+	}
+	return m.opts.Initial
+}
+
+// diff returns the rating difference in favor of the first team, including home and close advantages.
+func (m *EloModel) diff(mu Matchup) float64 {
+	d := m.Rating(mu.Team1) - m.Rating(mu.Team2)
+	switch mu.Location {
+	case Home:
+		d += m.opts.HomeAdvantage
+	case Near:
+		d += m.opts.CloseAdvantage
+	case Far:
+		d -= m.opts.CloseAdvantage
+	case Away:
+		d -= m.opts.HomeAdvantage
+	}
+	return d
+}
+
+func eloProbability(diff float64) float64 {
+	return 1 / (1 + math.Pow(10, -diff/400))
+}
+
+// Predict returns the probability and spread for team1.  Special cases, in order of precidence:
+// Predict(NONE, NONE, loc): (NaN, NaN, error)
+// Predict(NONE, t2, loc): (0, 0, nil)
+// Predict(t1, NONE, loc): (1, 0, nil)
+func (m *EloModel) Predict(mu Matchup) (float64, float64, error) {
+	if mu.Team1 == nil && mu.Team2 == nil {
+		// Both teams have a bye week, so the winner is undefined.
+		return math.NaN(), math.NaN(), fmt.Errorf("cannot predict a null game")
+	}
+	if mu.Team1 == nil {
+		// The second team has a bye week, so wins automatically.
+		return 0., 0., nil
+	}
+	if mu.Team2 == nil {
+		// The first team has a bye week, so wins automatically.
+		return 1., 0., nil
+	}
+	d := m.diff(mu)
+	return eloProbability(d), d / m.opts.EloPerPoint, nil
+}
+
+// Update adjusts the ratings of both teams of a Matchup after the first team wins by the given margin (negative if it lost).
+//
+// The rating change is K times the difference between the result and the predicted probability of win, multiplied by
+// ln(|margin|+1) to reward larger wins.  The multiplier is damped when the favorite wins, so that ratings of strong teams
+// do not run away from blowing out weak teams.  Ties exchange points without a multiplier.
+func (m *EloModel) Update(mu Matchup, margin int) {
+	d := m.diff(mu)
+	result, mult := .5, 1.
+	switch {
+	case margin > 0:
+		result = 1
+		mult = movMultiplier(margin, d)
+	case margin < 0:
+		result = 0
+		mult = movMultiplier(-margin, -d)
+	}
+	delta := m.opts.K * mult * (result - eloProbability(d))
+	m.ratings[mu.Team1] = m.Rating(mu.Team1) + delta
+	m.ratings[mu.Team2] = m.Rating(mu.Team2) - delta
+}
+
+// minMOVDenominator bounds the denominator of the margin-of-victory multiplier away from zero, so that an upset by a team
+// trailing by 1200 rating points or more is rewarded as if it trailed by 1200.
+const minMOVDenominator = 1.
+
+// movMultiplier returns the margin-of-victory multiplier for a win by the given margin by a team favored by winnerDiff rating points.
+func movMultiplier(margin int, winnerDiff float64) float64 {
+	return math.Log(float64(margin)+1) * 2.2 / math.Max(winnerDiff*.001+2.2, minMOVDenominator)
+}
+
+// Regress moves every rating toward the initial rating by the Regression fraction, as is done between seasons.
+func (m *EloModel) Regress() {
+	for t, r := range m.ratings {
+		m.ratings[t] = r + m.opts.Regression*(m.opts.Initial-r)
+	}
+}
+
+// FitElo makes an EloModel and updates it with every completed Game in order of start time.  Ratings are regressed each time
+// the season changes.  Each game is a Matchup of its home team against its away team, at the location found by GameLocation,
+// with the Teams and Venues it refers to looked up by document ID in the maps returned by Store.Teams and Store.Venues.
+// The venues map may be nil, in which case neutral sites are Neutral and CloseAdvantage is never used.
+func FitElo(games map[string]*Game, teams map[string]*Team, venues map[string]*Venue, opts EloOptions) (*EloModel, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	m := NewEloModel(opts)
+	season := ""
+	for i, g := range completedGames(games) {
+		mu, err := gameMatchup(g, teams, venues)
+		if err != nil {
+			return nil, err
+		}
+
+		s := ""
+		if g.Season != nil {
+			s = g.Season.ID
+		}
+		if i > 0 && s != season {
+			m.Regress()
+		}
+		season = s
+
+		m.Update(mu, int(g.margin()))
+	}
+	return m, nil
+}
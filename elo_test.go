@@ -0,0 +1,157 @@
+package pickem
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEloModel_Predict(t *testing.T) {
+	teamA := fakeTeam("A")
+	teamB := fakeTeam("B")
+	m := NewEloModel(DefaultEloOptions)
+	m.ratings[teamA] = 1600
+	m.ratings[teamB] = 1500
+
+	tests := []struct {
+		name       string
+		mu         Matchup
+		wantProb   float64
+		wantSpread float64
+		wantErr    bool
+	}{
+		{name: "neutral", mu: Matchup{Team1: teamA, Team2: teamB, Location: Neutral}, wantProb: 1 / (1 + math.Pow(10, -.25)), wantSpread: 4},
+		{name: "reversed", mu: Matchup{Team1: teamB, Team2: teamA, Location: Neutral}, wantProb: 1 / (1 + math.Pow(10, .25)), wantSpread: -4},
+		{name: "home", mu: Matchup{Team1: teamA, Team2: teamB, Location: Home}, wantProb: 1 / (1 + math.Pow(10, -165./400)), wantSpread: 6.6},
+		{name: "away", mu: Matchup{Team1: teamA, Team2: teamB, Location: Away}, wantProb: 1 / (1 + math.Pow(10, -35./400)), wantSpread: 1.4},
+		{name: "near", mu: Matchup{Team1: teamA, Team2: teamB, Location: Near}, wantProb: 1 / (1 + math.Pow(10, -125./400)), wantSpread: 5},
+		{name: "far", mu: Matchup{Team1: teamA, Team2: teamB, Location: Far}, wantProb: 1 / (1 + math.Pow(10, -75./400)), wantSpread: 3},
+		{name: "unrated", mu: Matchup{Team1: fakeTeam("C"), Team2: teamB, Location: Neutral}, wantProb: .5, wantSpread: 0},
+		{name: "bye 1", mu: Matchup{Team1: nil, Team2: teamB, Location: Neutral}, wantProb: 0, wantSpread: 0},
+		{name: "bye 2", mu: Matchup{Team1: teamA, Team2: nil, Location: Neutral}, wantProb: 1, wantSpread: 0},
+		{name: "null", mu: Matchup{Team1: nil, Team2: nil, Location: Neutral}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, s, err := m.Predict(tt.mu)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Predict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if math.Abs(p-tt.wantProb) > 1e-12 {
+				t.Errorf("Predict() prob = %v, want %v", p, tt.wantProb)
+			}
+			if math.Abs(s-tt.wantSpread) > 1e-12 {
+				t.Errorf("Predict() spread = %v, want %v", s, tt.wantSpread)
+			}
+		})
+	}
+}
+
+func TestEloModel_Update(t *testing.T) {
+	teamA := fakeTeam("A")
+	teamB := fakeTeam("B")
+	m := NewEloModel(DefaultEloOptions)
+
+	m.Update(Matchup{Team1: teamA, Team2: teamB, Location: Neutral}, 7)
+	want := 20 * math.Log(8) * .5
+	if got := m.Rating(teamA) - 1500; math.Abs(got-want) > 1e-9 {
+		t.Errorf("winner gained %v, want %v", got, want)
+	}
+	if got := 1500 - m.Rating(teamB); math.Abs(got-want) > 1e-9 {
+		t.Errorf("loser lost %v, want %v", got, want)
+	}
+
+	// A favorite winning by the same margin gains less than an underdog would.
+	before := m.Rating(teamA)
+	m.Update(Matchup{Team1: teamA, Team2: teamB, Location: Home}, 7)
+	favoriteGain := m.Rating(teamA) - before
+	before = m.Rating(teamB)
+	m.Update(Matchup{Team1: teamA, Team2: teamB, Location: Home}, -7)
+	underdogGain := m.Rating(teamB) - before
+	if favoriteGain <= 0 || favoriteGain >= underdogGain {
+		t.Errorf("expected 0 < favorite gain %v < underdog gain %v", favoriteGain, underdogGain)
+	}
+
+	// Evenly matched teams that tie do not move.
+	teamC := fakeTeam("C")
+	teamD := fakeTeam("D")
+	m.Update(Matchup{Team1: teamC, Team2: teamD, Location: Neutral}, 0)
+	if m.Rating(teamC) != 1500 || m.Rating(teamD) != 1500 {
+		t.Errorf("expected tie between even teams to leave ratings unchanged, got %v and %v", m.Rating(teamC), m.Rating(teamD))
+	}
+
+	m.ratings[teamC] = 1560
+	m.Regress()
+	if got := m.Rating(teamC); math.Abs(got-1540) > 1e-9 {
+		t.Errorf("regressed rating = %v, want 1540", got)
+	}
+}
+
+func TestFitElo(t *testing.T) {
+	teams := map[string]*Team{"A": fakeTeam("A"), "B": fakeTeam("B")}
+	teams["A"].HomeVenue = detachedRef(VenuesCollection, "a")
+	teams["B"].HomeVenue = detachedRef(VenuesCollection, "b")
+	venues := map[string]*Venue{
+		"a":    {Name: "A Field", LatLonAlt: []float64{40, -100}},
+		"b":    {Name: "B Field", LatLonAlt: []float64{40, -80}},
+		"near": {Name: "Near A", LatLonAlt: []float64{40, -98}},
+	}
+	start := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	g2018 := completedGame("A", "B", 28, 21, true, start)
+	g2018.Season = detachedRef(SeasonsCollection, "2018")
+	g2019 := completedGame("B", "A", 14, 21, true, start.AddDate(1, 0, 0))
+	g2019.Season = detachedRef(SeasonsCollection, "2019")
+	g2019.Venue = detachedRef(VenuesCollection, "near")
+	unplayed := &Game{HomeTeam: detachedRef(TeamsCollection, "B"), AwayTeam: detachedRef(TeamsCollection, "A")}
+	games := map[string]*Game{"2": g2019, "1": g2018, "3": unplayed}
+
+	got, err := FitElo(games, teams, venues, DefaultEloOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The 2019 game is at a neutral site near A, so it is Far for B, the home team.
+	want := NewEloModel(DefaultEloOptions)
+	want.Update(Matchup{Team1: teams["A"], Team2: teams["B"], Location: Neutral}, 7)
+	want.Regress()
+	want.Update(Matchup{Team1: teams["B"], Team2: teams["A"], Location: Far}, -7)
+	for name, team := range teams {
+		if got.Rating(team) != want.Rating(team) {
+			t.Errorf("rating of %s = %v, want %v", name, got.Rating(team), want.Rating(team))
+		}
+	}
+
+	if _, err := FitElo(map[string]*Game{"1": completedGame("A", "Z", 1, 0, false, start)}, teams, venues, DefaultEloOptions); err == nil {
+		t.Errorf("expected error for unknown team")
+	}
+	bad := DefaultEloOptions
+	bad.K = 0
+	if _, err := FitElo(games, teams, venues, bad); err == nil {
+		t.Errorf("expected error for invalid options")
+	}
+}
+
+func TestMovMultiplier(t *testing.T) {
+	if got, want := movMultiplier(7, 0), math.Log(8); math.Abs(got-want) > 1e-12 {
+		t.Errorf("even multiplier = %v, want %v", got, want)
+	}
+	for _, d := range []float64{-2200, -5000} {
+		if got, want := movMultiplier(7, d), math.Log(8)*2.2; math.IsInf(got, 0) || got <= 0 || math.Abs(got-want) > 1e-12 {
+			t.Errorf("multiplier for a %v-point underdog = %v, want %v", d, got, want)
+		}
+	}
+
+	// A huge upset still moves ratings toward the winner.
+	teamA := fakeTeam("A")
+	teamB := fakeTeam("B")
+	m := NewEloModel(DefaultEloOptions)
+	m.ratings[teamA] = 4000
+	m.ratings[teamB] = 1000
+	m.Update(Matchup{Team1: teamA, Team2: teamB, Location: Neutral}, -7)
+	if m.Rating(teamB) <= 1000 || m.Rating(teamA) >= 4000 {
+		t.Errorf("expected upset to move ratings toward the winner, got %v and %v", m.Rating(teamA), m.Rating(teamB))
+	}
+}
@@ -0,0 +1,311 @@
+package pickem
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/atgjack/prob"
+)
+
+// EnsembleModel implements MatchupPredicter by combining the spreads of several component MatchupPredicters.
+//
+// The spread of the ensemble is the weighted mean of the component spreads.  The probability of win is the Normal CDF of the
+// spread, with a standard deviation that combines the ensemble's own standard deviation with the weighted standard
+// deviation of the component spreads about their mean, so that the ensemble is less confident when its components disagree.
+type EnsembleModel struct {
+	models  []MatchupPredicter
+	weights []float64
+	stdDev  float64
+}
+
+// NewEnsembleModel makes a model from component models and their weights.  The weights are normalized to sum to one.
+func NewEnsembleModel(models []MatchupPredicter, weights []float64, stdDev float64) *EnsembleModel {
+	if len(models) == 0 {
+		panic(fmt.Errorf("ensemble must have at least one model"))
+	}
+	if len(models) != len(weights) {
+		panic(fmt.Errorf("mismatched length of models (%d) and weights (%d)", len(models), len(weights)))
+	}
+	if stdDev <= 0 {
+		panic(fmt.Errorf("standard deviation must be positive, got %f", stdDev))
+	}
+	sum := 0.
+	for i, w := range weights {
+		if w < 0 {
+			panic(fmt.Errorf("weight %d must not be negative, got %f", i, w))
+		}
+		sum += w
+	}
+	if sum == 0 {
+		panic(fmt.Errorf("weights must not all be zero"))
+	}
+	norm := make([]float64, len(weights))
+	for i, w := range weights {
+		norm[i] = w / sum
+	}
+	return &EnsembleModel{models: models, weights: norm, stdDev: stdDev}
+}
+
+// Weights returns the normalized weight of each component model.
+func (m *EnsembleModel) Weights() []float64 {
+	return append([]float64(nil), m.weights...)
+}
+
+// Disagreement returns the spread predicted by each component model for the first team, along with the weighted standard
+// deviation of the component spreads about the ensemble spread.
+func (m *EnsembleModel) Disagreement(mu Matchup) (spreads []float64, stdDev float64, err error) {
+	spreads = make([]float64, len(m.models))
+	mean := 0.
+	for i, model := range m.models {
+		if _, spreads[i], err = model.Predict(mu); err != nil {
+			return nil, 0., fmt.Errorf("model %d failed to predict: %v", i, err)
+		}
+		mean += m.weights[i] * spreads[i]
+	}
+	v := 0.
+	for i, s := range spreads {
+		v += m.weights[i] * (s - mean) * (s - mean)
+	}
+	return spreads, math.Sqrt(v), nil
+}
+
+// Predict returns the probability and spread for team1.  Special cases, in order of precidence:
+// Predict(NONE, NONE, loc): (NaN, NaN, error)
+// Predict(NONE, t2, loc): (0, 0, nil)
+// Predict(t1, NONE, loc): (1, 0, nil)
+func (m *EnsembleModel) Predict(mu Matchup) (float64, float64, error) {
+	if mu.Team1 == nil && mu.Team2 == nil {
+		// Both teams have a bye week, so the winner is undefined.
+		return math.NaN(), math.NaN(), fmt.Errorf("cannot predict a null game")
+	}
+	if mu.Team1 == nil {
+		// The second team has a bye week, so wins automatically.
+		return 0., 0., nil
+	}
+	if mu.Team2 == nil {
+		// The first team has a bye week, so wins automatically.
+		return 1., 0., nil
+	}
+	spreads, disagreement, err := m.Disagreement(mu)
+	if err != nil {
+		return 0., 0., err
+	}
+	spread := 0.
+	for i, s := range spreads {
+		spread += m.weights[i] * s
+	}
+	dist := prob.Normal{Mu: 0, Sigma: math.Hypot(m.stdDev, disagreement)}
+	return dist.Cdf(spread), spread, nil
+}
+
+// FitEnsemble fits the weights of the component models to the margins of the completed Games by least squares, with the
+// weights constrained to be non-negative and to sum to one, as solved exactly by an active-set method.  Games become Matchups
+// as in FitElo, so the venues map may be nil.
+//
+// Predict adds the components' disagreement to the ensemble's standard deviation, so the standard deviation is fit net of it:
+// its square is the mean squared residual less the mean squared disagreement.  An error is returned if the components
+// disagree by more than the residuals, because the disagreement alone then overstates the uncertainty of the ensemble.
+//
+// Models that predict the same spreads as a combination of the others cannot be told apart, and cause an error.
+func FitEnsemble(models []MatchupPredicter, games map[string]*Game, teams map[string]*Team, venues map[string]*Venue) (*EnsembleModel, error) {
+	if len(models) == 0 {
+		return nil, fmt.Errorf("ensemble must have at least one model")
+	}
+	completed := completedGames(games)
+	if len(completed) == 0 {
+		return nil, fmt.Errorf("no completed games to fit")
+	}
+
+	// x[g][k] is the spread predicted by model k for the home team of game g.
+	x := make([][]float64, len(completed))
+	y := make([]float64, len(completed))
+	for g, game := range completed {
+		mu, err := gameMatchup(game, teams, venues)
+		if err != nil {
+			return nil, err
+		}
+		x[g] = make([]float64, len(models))
+		for k, model := range models {
+			if _, x[g][k], err = model.Predict(mu); err != nil {
+				return nil, fmt.Errorf("model %d failed to predict: %v", k, err)
+			}
+		}
+		y[g] = game.margin()
+	}
+
+	weights, err := simplexNNLS(x, y)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fit ensemble: %v", err)
+	}
+
+	sse, ssd := 0., 0.
+	for g := range x {
+		mean := 0.
+		for k, w := range weights {
+			mean += w * x[g][k]
+		}
+		r := y[g] - mean
+		sse += r * r
+		for k, w := range weights {
+			ssd += w * (x[g][k] - mean) * (x[g][k] - mean)
+		}
+	}
+	if sse == 0 {
+		return nil, fmt.Errorf("games fit perfectly, so standard deviation cannot be estimated")
+	}
+	if ssd >= sse {
+		return nil, fmt.Errorf("models disagree more than they err, so standard deviation cannot be estimated")
+	}
+	stdDev := math.Sqrt((sse - ssd) / float64(len(x)))
+	return NewEnsembleModel(models, weights, stdDev), nil
+}
+
+// nnlsIterations bounds the number of changes to the active set made by simplexNNLS.
+const nnlsIterations = 1000
+
+// simplexNNLS minimizes the squared error of y predicted by a weighted sum of the columns of x, with the weights constrained to
+// be non-negative and to sum to one.
+//
+// This is a primal active-set method in the style of Lawson and Hanson.  Starting from the best single column, it repeatedly
+// solves the equality-constrained problem over the free (passive) columns.  If that solution has negative weights, it steps
+// toward it only as far as feasibility allows and fixes the weights that reach zero.  Otherwise, it frees the fixed column
+// whose gradient most violates the optimality conditions, stopping when none do.
+func simplexNNLS(x [][]float64, y []float64) ([]float64, error) {
+	n := len(x[0])
+	sse := func(w []float64) float64 {
+		e := 0.
+		for g, xg := range x {
+			r := y[g]
+			for k, wk := range w {
+				r -= wk * xg[k]
+			}
+			e += r * r
+		}
+		return e
+	}
+
+	// The weights are not unique if the columns are not independent.
+	all := make([]bool, n)
+	for k := range all {
+		all[k] = true
+	}
+	if _, err := simplexLeastSquares(x, y, all); err != nil {
+		return nil, err
+	}
+
+	// Start from the best single column, which is feasible.
+	passive := make([]bool, n)
+	w := make([]float64, n)
+	best := -1
+	bestSSE := math.Inf(1)
+	for k := 0; k < n; k++ {
+		e := make([]float64, n)
+		e[k] = 1
+		if s := sse(e); s < bestSSE {
+			best, bestSSE = k, s
+		}
+	}
+	passive[best] = true
+	w[best] = 1
+
+	for iter := 0; iter < nnlsIterations; iter++ {
+		z, err := simplexLeastSquares(x, y, passive)
+		if err != nil {
+			return nil, err
+		}
+
+		// Step toward z as far as possible while keeping the weights non-negative.
+		alpha := 1.
+		for k, ok := range passive {
+			if ok && z[k] < 0 {
+				if a := w[k] / (w[k] - z[k]); a < alpha {
+					alpha = a
+				}
+			}
+		}
+		if alpha < 1 {
+			for k := range w {
+				w[k] += alpha * (z[k] - w[k])
+				if passive[k] && w[k] <= 1e-12 {
+					passive[k] = false
+					w[k] = 0
+				}
+			}
+			continue
+		}
+		copy(w, z)
+
+		// g is the negative gradient of half the squared error.  At the optimum it is equal across the passive columns and no
+		// larger for any fixed column.
+		g := make([]float64, n)
+		for gi, xg := range x {
+			r := y[gi]
+			for k, wk := range w {
+				r -= wk * xg[k]
+			}
+			for k := range g {
+				g[k] += xg[k] * r
+			}
+		}
+		lambda, nPassive, scale := 0., 0, 1.
+		for k, ok := range passive {
+			if ok {
+				lambda += g[k]
+				nPassive++
+			}
+			scale = math.Max(scale, math.Abs(g[k]))
+		}
+		lambda /= float64(nPassive)
+		enter := -1
+		for k, ok := range passive {
+			if !ok && g[k]-lambda > 1e-10*scale && (enter < 0 || g[k] > g[enter]) {
+				enter = k
+			}
+		}
+		if enter < 0 {
+			return w, nil
+		}
+		passive[enter] = true
+	}
+	return nil, fmt.Errorf("active set did not converge")
+}
+
+// simplexLeastSquares minimizes the squared error of y predicted by a weighted sum of the active columns of x, with the weights
+// constrained to sum to one.  Inactive columns get zero weight.  The constraint is enforced with a Lagrange multiplier.
+func simplexLeastSquares(x [][]float64, y []float64, active []bool) ([]float64, error) {
+	cols := make([]int, 0, len(active))
+	for k, ok := range active {
+		if ok {
+			cols = append(cols, k)
+		}
+	}
+	n := len(cols)
+	a := make([][]float64, n+1)
+	for i := range a {
+		a[i] = make([]float64, n+1)
+	}
+	b := make([]float64, n+1)
+	for g, xg := range x {
+		for i, ki := range cols {
+			b[i] += xg[ki] * y[g]
+			for j, kj := range cols {
+				a[i][j] += xg[ki] * xg[kj]
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		a[i][n] = 1
+		a[n][i] = 1
+	}
+	b[n] = 1
+
+	sol, err := solveLinear(a, b)
+	if err != nil {
+		return nil, err
+	}
+	weights := make([]float64, len(active))
+	for i, k := range cols {
+		weights[k] = sol[i]
+	}
+	return weights, nil
+}
@@ -0,0 +1,242 @@
+package pickem
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/atgjack/prob"
+)
+
+// spreadPredicter predicts matchups by looking up the spread of the first team, with a probability of win of one half.
+type spreadPredicter map[teamPair]float64
+
+func (sp spreadPredicter) Predict(m Matchup) (float64, float64, error) {
+	s, ok := sp[teamPair{m.Team1, m.Team2}]
+	if !ok {
+		return 0, 0, fmt.Errorf("no prediction")
+	}
+	return .5, s, nil
+}
+
+// locationPredicter predicts matchups by looking up the spread of the first team by location, with a probability of win of one half.
+type locationPredicter map[RelativeLocation]float64
+
+func (lp locationPredicter) Predict(m Matchup) (float64, float64, error) {
+	return .5, lp[m.Location], nil
+}
+
+func TestEnsembleModel_Predict(t *testing.T) {
+	teamA := fakeTeam("A")
+	teamB := fakeTeam("B")
+	models := []MatchupPredicter{
+		spreadPredicter{teamPair{teamA, teamB}: 6},
+		spreadPredicter{teamPair{teamA, teamB}: 0},
+	}
+	m := NewEnsembleModel(models, []float64{2, 1}, 12)
+
+	if w := m.Weights(); math.Abs(w[0]-2./3.) > 1e-12 || math.Abs(w[1]-1./3.) > 1e-12 {
+		t.Errorf("expected normalized weights, got %v", w)
+	}
+
+	spreads, disagreement, err := m.Disagreement(Matchup{Team1: teamA, Team2: teamB, Location: Home})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spreads[0] != 6 || spreads[1] != 0 {
+		t.Errorf("expected component spreads [6 0], got %v", spreads)
+	}
+	wantDisagreement := math.Sqrt(2./3.*4 + 1./3.*16)
+	if math.Abs(disagreement-wantDisagreement) > 1e-12 {
+		t.Errorf("disagreement = %v, want %v", disagreement, wantDisagreement)
+	}
+
+	p, s, err := m.Predict(Matchup{Team1: teamA, Team2: teamB, Location: Home})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(s-4) > 1e-12 {
+		t.Errorf("spread = %v, want 4", s)
+	}
+	wantP := prob.Normal{Mu: 0, Sigma: math.Hypot(12, wantDisagreement)}.Cdf(4)
+	if math.Abs(p-wantP) > 1e-12 {
+		t.Errorf("prob = %v, want %v", p, wantP)
+	}
+
+	if p, _, err := m.Predict(Matchup{Team1: teamA, Team2: nil, Location: Home}); err != nil || p != 1 {
+		t.Errorf("expected bye to win, got %v, %v", p, err)
+	}
+	if _, _, err := m.Predict(Matchup{Team1: teamB, Team2: teamA, Location: Home}); err == nil {
+		t.Errorf("expected error from component model")
+	}
+}
+
+func TestFitEnsemble(t *testing.T) {
+	names := []string{"A", "B", "C", "D"}
+	teams := make(map[string]*Team)
+	for _, n := range names {
+		teams[n] = fakeTeam(n)
+	}
+	good := make(spreadPredicter)
+	bad := make(spreadPredicter)
+	contrary := make(spreadPredicter)
+	games := make(map[string]*Game)
+	start := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	i := 0
+	for hi, home := range names {
+		for ai, away := range names {
+			if home == away {
+				continue
+			}
+			truth := float64(3 * (ai - hi))
+			noise := float64(i%3 - 1)
+			pair := teamPair{teams[home], teams[away]}
+			good[pair] = truth
+			bad[pair] = float64(i%5) - 2
+			contrary[pair] = -truth + float64(i%2)
+			games[fmt.Sprintf("%02d", i)] = completedGame(home, away, 30+int(truth+noise), 30, false, start.Add(time.Duration(i)*time.Hour))
+			i++
+		}
+	}
+
+	m, err := FitEnsemble([]MatchupPredicter{good, bad, contrary}, games, teams, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := m.Weights()
+	sum := 0.
+	for k, wk := range w {
+		if wk < 0 {
+			t.Errorf("weight %d is negative: %v", k, wk)
+		}
+		sum += wk
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("weights sum to %v, want 1", sum)
+	}
+	if w[0] < .9 || w[2] != 0 {
+		t.Errorf("expected the good model to dominate and the contrary model to be dropped, got %v", w)
+	}
+
+	if _, err := FitEnsemble([]MatchupPredicter{good, good}, games, teams, nil); err == nil {
+		t.Errorf("expected error for indistinguishable models")
+	}
+	if _, err := FitEnsemble(nil, games, teams, nil); err == nil {
+		t.Errorf("expected error for no models")
+	}
+}
+
+func TestFitEnsemble_ActiveSet(t *testing.T) {
+	// Fitting all three models gives the second a negative weight, but the best fit uses the first and second models together.
+	// Dropping the most negative weight and refitting never brings the second model back.
+	spreads := [][3]float64{{3, 8, -8}, {-2, -3, 5}, {-3, -6, -8}, {-1, 5, -10}}
+	margins := []int{4, -6, 1, 1}
+	models := []spreadPredicter{{}, {}, {}}
+	teams := make(map[string]*Team)
+	games := make(map[string]*Game)
+	start := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	for i, s := range spreads {
+		home, away := fmt.Sprintf("H%d", i), fmt.Sprintf("A%d", i)
+		teams[home], teams[away] = fakeTeam(home), fakeTeam(away)
+		for k, m := range models {
+			m[teamPair{teams[home], teams[away]}] = s[k]
+		}
+		games[fmt.Sprintf("%02d", i)] = completedGame(home, away, 30+margins[i], 30, false, start.Add(time.Duration(i)*time.Hour))
+	}
+
+	m, err := FitEnsemble([]MatchupPredicter{models[0], models[1], models[2]}, games, teams, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{62. / 71., 9. / 71., 0}
+	for k, w := range m.Weights() {
+		if math.Abs(w-want[k]) > 1e-9 {
+			t.Errorf("weights = %v, want %v", m.Weights(), want)
+			break
+		}
+	}
+}
+
+func TestFitEnsemble_Location(t *testing.T) {
+	teams := map[string]*Team{"A": fakeTeam("A"), "B": fakeTeam("B")}
+	teams["A"].HomeVenue = detachedRef(VenuesCollection, "a")
+	teams["B"].HomeVenue = detachedRef(VenuesCollection, "b")
+	venues := map[string]*Venue{
+		"a":    {Name: "A Field", LatLonAlt: []float64{40, -100}},
+		"b":    {Name: "B Field", LatLonAlt: []float64{40, -80}},
+		"near": {Name: "Near A", LatLonAlt: []float64{40, -98}},
+	}
+	games := make(map[string]*Game)
+	start := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		g := completedGame("A", "B", 30+2+2*(i%2), 30, true, start.Add(time.Duration(i)*time.Hour))
+		g.Venue = detachedRef(VenuesCollection, "near")
+		games[fmt.Sprintf("%02d", i)] = g
+	}
+
+	// Only the first model predicts the margins of games near the home team, but the second predicts them if they are Neutral.
+	near := locationPredicter{Near: 3}
+	neutral := locationPredicter{Neutral: 3}
+	m, err := FitEnsemble([]MatchupPredicter{near, neutral}, games, teams, venues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := m.Weights(); math.Abs(w[0]-1) > 1e-9 {
+		t.Errorf("expected the model of games near the home team to be chosen, got weights %v", w)
+	}
+}
+
+func TestFitEnsemble_Calibration(t *testing.T) {
+	// Two models see the true spread with independent errors larger than the noise in the outcomes, so much of the ensemble's
+	// error shows up as disagreement between the models.
+	const outcomeNoise, modelNoise = 3., 8.
+	rng := rand.New(rand.NewSource(42))
+	makeGames := func(prefix string, n int) (map[string]*Game, map[string]*Team, []spreadPredicter) {
+		games := make(map[string]*Game)
+		teams := make(map[string]*Team)
+		models := []spreadPredicter{{}, {}}
+		start := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+		for i := 0; i < n; i++ {
+			home, away := fmt.Sprintf("%sH%d", prefix, i), fmt.Sprintf("%sA%d", prefix, i)
+			teams[home], teams[away] = fakeTeam(home), fakeTeam(away)
+			truth := rng.Float64()*40 - 20
+			for _, m := range models {
+				m[teamPair{teams[home], teams[away]}] = truth + rng.NormFloat64()*modelNoise
+			}
+			margin := int(math.Round(truth + rng.NormFloat64()*outcomeNoise))
+			games[fmt.Sprintf("%s%05d", prefix, i)] = completedGame(home, away, 100+margin, 100, false, start.Add(time.Duration(i)*time.Minute))
+		}
+		return games, teams, models
+	}
+
+	games, teams, models := makeGames("fit", 4000)
+	m, err := FitEnsemble([]MatchupPredicter{models[0], models[1]}, games, teams, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// On new games, the mean squared residual matches the mean variance predicted from the standard deviation and the
+	// disagreement if the ensemble is calibrated.  Counting the disagreement twice would overstate the variance by half.
+	games, teams, models = makeGames("test", 4000)
+	m.models = []MatchupPredicter{models[0], models[1]}
+	sse, variance := 0., 0.
+	for _, g := range games {
+		mu := Matchup{Team1: teams[g.HomeTeam.ID], Team2: teams[g.AwayTeam.ID], Location: Home}
+		_, spread, err := m.Predict(mu)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, disagreement, err := m.Disagreement(mu)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := g.margin() - spread
+		sse += r * r
+		variance += m.stdDev*m.stdDev + disagreement*disagreement
+	}
+	if ratio := sse / variance; math.Abs(ratio-1) > .1 {
+		t.Errorf("ratio of squared residuals to predicted variance = %v, want 1", ratio)
+	}
+}
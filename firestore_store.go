@@ -0,0 +1,336 @@
+package pickem
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// firestoreBatchSize is the number of writes committed to Firestore at once, which is Firestore's limit for a batch.
+const firestoreBatchSize = 500
+
+// FirestoreStore implements Store using Google Cloud Firestore.
+//
+// Unlike the other Stores, writes are only atomic up to firestoreBatchSize documents.  Larger writes are committed in
+// several batches, so a failure partway through leaves the earlier batches written.
+type FirestoreStore struct {
+	fs *firestore.Client
+}
+
+// NewFirestoreStore makes a Store backed by the given Firestore client.
+func NewFirestoreStore(fs *firestore.Client) *FirestoreStore {
+	return &FirestoreStore{fs: fs}
+}
+
+// Ref implements Store.
+func (s *FirestoreStore) Ref(collection, id string) *firestore.DocumentRef {
+	return s.fs.Collection(collection).Doc(id)
+}
+
+// Close implements Store and closes the underlying Firestore client.
+func (s *FirestoreStore) Close() error {
+	return s.fs.Close()
+}
+
+// attach implements refMapper by converting references into references valid for this Store.
+func (s *FirestoreStore) attach(collection string, ref *firestore.DocumentRef) *firestore.DocumentRef {
+	if ref == nil {
+		return nil
+	}
+	return s.Ref(collection, ref.ID)
+}
+
+func (s *FirestoreStore) get(ctx context.Context, collection, id string, p interface{}) error {
+	doc, err := s.fs.Collection(collection).Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("%s/%s: %w", collection, id, ErrNotFound)
+	}
+	if err != nil {
+		return err
+	}
+	return doc.DataTo(p)
+}
+
+// query calls f with every document returned by the query.
+func (s *FirestoreStore) query(ctx context.Context, q firestore.Query, f func(*firestore.DocumentSnapshot) error) error {
+	itr := q.Documents(ctx)
+	defer itr.Stop()
+	for {
+		doc, err := itr.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := f(doc); err != nil {
+			return err
+		}
+	}
+}
+
+// put writes the documents in a single atomic batch if there are at most firestoreBatchSize of them, otherwise in several batches.
+func (s *FirestoreStore) put(ctx context.Context, collection string, docs map[string]interface{}, overwrite bool) error {
+	col := s.fs.Collection(collection)
+	wb := s.fs.Batch()
+	n := 0
+	for id, data := range docs {
+		if overwrite {
+			wb.Set(col.Doc(id), data)
+		} else {
+			wb.Create(col.Doc(id), data)
+		}
+		n++
+		if n%firestoreBatchSize == 0 {
+			if _, err := wb.Commit(ctx); err != nil {
+				return s.writeError(collection, err)
+			}
+			wb = s.fs.Batch()
+		}
+	}
+	if n%firestoreBatchSize != 0 {
+		if _, err := wb.Commit(ctx); err != nil {
+			return s.writeError(collection, err)
+		}
+	}
+	return nil
+}
+
+func (s *FirestoreStore) writeError(collection string, err error) error {
+	if status.Code(err) == codes.AlreadyExists {
+		return fmt.Errorf("%s: %v: %w", collection, err, ErrExists)
+	}
+	return err
+}
+
+func (s *FirestoreStore) teams(ctx context.Context, q firestore.Query) (map[string]*Team, error) {
+	teams := make(map[string]*Team)
+	err := s.query(ctx, q, func(doc *firestore.DocumentSnapshot) error {
+		var t Team
+		if err := doc.DataTo(&t); err != nil {
+			return err
+		}
+		teams[doc.Ref.ID] = &t
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// Team implements Store.
+func (s *FirestoreStore) Team(ctx context.Context, id string) (*Team, error) {
+	var t Team
+	if err := s.get(ctx, TeamsCollection, id, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Teams implements Store.
+func (s *FirestoreStore) Teams(ctx context.Context) (map[string]*Team, error) {
+	return s.teams(ctx, s.fs.Collection(TeamsCollection).Query)
+}
+
+// TeamsByAbbreviation implements Store.
+func (s *FirestoreStore) TeamsByAbbreviation(ctx context.Context, abbreviation string) (map[string]*Team, error) {
+	return s.teams(ctx, s.fs.Collection(TeamsCollection).Where("abbreviation", "==", abbreviation))
+}
+
+// TeamsByName implements Store.
+func (s *FirestoreStore) TeamsByName(ctx context.Context, name string) (map[string]*Team, error) {
+	return s.teams(ctx, s.fs.Collection(TeamsCollection).Where("names", "array-contains", name))
+}
+
+// PutTeams implements Store.
+func (s *FirestoreStore) PutTeams(ctx context.Context, teams map[string]*Team, overwrite bool) error {
+	docs := make(map[string]interface{})
+	for id, t := range teams {
+		docs[id] = t.mapRefs(s.attach)
+	}
+	return s.put(ctx, TeamsCollection, docs, overwrite)
+}
+
+// SetHomeVenues implements Store.  Like put, the update is only atomic up to firestoreBatchSize teams.
+func (s *FirestoreStore) SetHomeVenues(ctx context.Context, venues map[string]*firestore.DocumentRef) error {
+	col := s.fs.Collection(TeamsCollection)
+	wb := s.fs.Batch()
+	n := 0
+	for id, venue := range venues {
+		wb.Update(col.Doc(id), []firestore.Update{{Path: "home_venue", Value: s.attach(VenuesCollection, venue)}})
+		n++
+		if n%firestoreBatchSize == 0 {
+			if _, err := wb.Commit(ctx); err != nil {
+				return s.updateError(err)
+			}
+			wb = s.fs.Batch()
+		}
+	}
+	if n%firestoreBatchSize != 0 {
+		if _, err := wb.Commit(ctx); err != nil {
+			return s.updateError(err)
+		}
+	}
+	return nil
+}
+
+func (s *FirestoreStore) updateError(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("%s: %v: %w", TeamsCollection, err, ErrNotFound)
+	}
+	return err
+}
+
+// Venue implements Store.
+func (s *FirestoreStore) Venue(ctx context.Context, id string) (*Venue, error) {
+	var v Venue
+	if err := s.get(ctx, VenuesCollection, id, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// Venues implements Store.
+func (s *FirestoreStore) Venues(ctx context.Context) (map[string]*Venue, error) {
+	venues := make(map[string]*Venue)
+	err := s.query(ctx, s.fs.Collection(VenuesCollection).Query, func(doc *firestore.DocumentSnapshot) error {
+		var v Venue
+		if err := doc.DataTo(&v); err != nil {
+			return err
+		}
+		venues[doc.Ref.ID] = &v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return venues, nil
+}
+
+// PutVenues implements Store.
+func (s *FirestoreStore) PutVenues(ctx context.Context, venues map[string]*Venue, overwrite bool) error {
+	docs := make(map[string]interface{})
+	for id, v := range venues {
+		docs[id] = v.mapRefs(s.attach)
+	}
+	return s.put(ctx, VenuesCollection, docs, overwrite)
+}
+
+// Games implements Store.  Games including a given Team are found with separate queries for home and road games.
+func (s *FirestoreStore) Games(ctx context.Context, q GameQuery) (map[string]*Game, error) {
+	query := s.fs.Collection(GamesCollection).Query
+	if q.Season != 0 {
+		query = query.Where("season", "==", SeasonRef(s, q.Season))
+	}
+	if q.MinWeek != 0 {
+		query = query.Where("week", ">=", q.MinWeek)
+	}
+	queries := []firestore.Query{query}
+	if q.Team != "" {
+		teamRef := s.Ref(TeamsCollection, q.Team)
+		queries = []firestore.Query{
+			query.Where("home_team", "==", teamRef),
+			query.Where("away_team", "==", teamRef),
+		}
+	}
+
+	games := make(map[string]*Game)
+	for _, query := range queries {
+		err := s.query(ctx, query, func(doc *firestore.DocumentSnapshot) error {
+			var g Game
+			if err := doc.DataTo(&g); err != nil {
+				return err
+			}
+			games[doc.Ref.ID] = &g
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return games, nil
+}
+
+// PutGames implements Store.
+func (s *FirestoreStore) PutGames(ctx context.Context, games map[string]*Game, overwrite bool) error {
+	docs := make(map[string]interface{})
+	for id, g := range games {
+		docs[id] = g.mapRefs(s.attach)
+	}
+	return s.put(ctx, GamesCollection, docs, overwrite)
+}
+
+// Season implements Store.
+func (s *FirestoreStore) Season(ctx context.Context, id string) (*Season, error) {
+	var season Season
+	if err := s.get(ctx, SeasonsCollection, id, &season); err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+// Seasons implements Store.
+func (s *FirestoreStore) Seasons(ctx context.Context) (map[string]*Season, error) {
+	seasons := make(map[string]*Season)
+	err := s.query(ctx, s.fs.Collection(SeasonsCollection).Query, func(doc *firestore.DocumentSnapshot) error {
+		var season Season
+		if err := doc.DataTo(&season); err != nil {
+			return err
+		}
+		seasons[doc.Ref.ID] = &season
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return seasons, nil
+}
+
+// PutSeasons implements Store.
+func (s *FirestoreStore) PutSeasons(ctx context.Context, seasons map[string]*Season, overwrite bool) error {
+	docs := make(map[string]interface{})
+	for id, season := range seasons {
+		docs[id] = season
+	}
+	return s.put(ctx, SeasonsCollection, docs, overwrite)
+}
+
+// Player implements Store.
+func (s *FirestoreStore) Player(ctx context.Context, id string) (*Player, error) {
+	var p Player
+	if err := s.get(ctx, PlayersCollection, id, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Players implements Store.
+func (s *FirestoreStore) Players(ctx context.Context) (map[string]*Player, error) {
+	players := make(map[string]*Player)
+	err := s.query(ctx, s.fs.Collection(PlayersCollection).Query, func(doc *firestore.DocumentSnapshot) error {
+		var p Player
+		if err := doc.DataTo(&p); err != nil {
+			return err
+		}
+		players[doc.Ref.ID] = &p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return players, nil
+}
+
+// PutPlayers implements Store.
+func (s *FirestoreStore) PutPlayers(ctx context.Context, players map[string]*Player, overwrite bool) error {
+	docs := make(map[string]interface{})
+	for id, p := range players {
+		docs[id] = p.mapRefs(s.attach)
+	}
+	return s.put(ctx, PlayersCollection, docs, overwrite)
+}
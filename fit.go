@@ -0,0 +1,221 @@
+package pickem
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// RatingsOptions controls how FitRatings weighs Games.
+type RatingsOptions struct {
+	// Ridge is the L2 penalty on team ratings, which shrinks the ratings of teams with few games toward zero.
+	Ridge float64
+	// HalfLife is the age at which a Game counts half as much as the most recent Game.  Zero weighs all Games equally.
+	HalfLife time.Duration
+}
+
+// completed returns true if the game has a final score.
+func (g *Game) completed() bool {
+	return g.HomePoints != nil && g.AwayPoints != nil && g.HomeTeam != nil && g.AwayTeam != nil
+}
+
+// margin returns the number of points by which the home team won.
+func (g *Game) margin() float64 {
+	return float64(*g.HomePoints - *g.AwayPoints)
+}
+
+// gameIDsByStart returns the IDs of the games in order of start time, then ID, so fits do not depend on map order.
+func gameIDsByStart(games map[string]*Game) []string {
+	ids := make([]string, 0, len(games))
+	for id := range games {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		ti, tj := games[ids[i]].StartTime, games[ids[j]].StartTime
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// completedGames returns the completed games in the order of gameIDsByStart.
+func completedGames(games map[string]*Game) []*Game {
+	var out []*Game
+	for _, id := range gameIDsByStart(games) {
+		if g := games[id]; g.completed() {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// gameMatchup returns the Matchup of a Game with the home team first, at the location relative to the home team found as by
+// GameLocation.  The teams and venues maps look up the Teams and Venues referenced by the Game by document ID, as returned by
+// Store.Teams and Store.Venues.  The venues map may be nil, in which case every neutral site is Neutral.
+func gameMatchup(g *Game, teams map[string]*Team, venues map[string]*Venue) (Matchup, error) {
+	home, ok := teams[g.HomeTeam.ID]
+	if !ok {
+		return Matchup{}, fmt.Errorf("team '%s' not found", g.HomeTeam.ID)
+	}
+	away, ok := teams[g.AwayTeam.ID]
+	if !ok {
+		return Matchup{}, fmt.Errorf("team '%s' not found", g.AwayTeam.ID)
+	}
+	loc, err := homeLocation(g, home, away, func(id string) (*Venue, error) { return venues[id], nil })
+	if err != nil {
+		return Matchup{}, err
+	}
+	return Matchup{Team1: home, Team2: away, Location: loc}, nil
+}
+
+// recencyWeights weighs each game by its age relative to the latest game, halving every halfLife.
+func recencyWeights(games []*Game, halfLife time.Duration) []float64 {
+	weights := make([]float64, len(games))
+	var latest time.Time
+	for _, g := range games {
+		if g.StartTime.After(latest) {
+			latest = g.StartTime
+		}
+	}
+	for i, g := range games {
+		weights[i] = 1.
+		if halfLife > 0 {
+			weights[i] = math.Exp2(-float64(latest.Sub(g.StartTime)) / float64(halfLife))
+		}
+	}
+	return weights
+}
+
+// FitRatings fits team ratings and a home field bias to the margins of the completed Games by weighted least squares,
+// returning a GaussianSpreadModel that predicts with them.  Games without a final score are ignored.
+//
+// The teams map looks up the Teams referenced by the Games by document ID, as returned by Store.Teams.  The home team of a
+// game not played at a neutral site is expected to win by the difference in ratings plus the home bias.  Ratings are only
+// determined up to a constant, so they are fixed to sum to zero.  The model's standard deviation is the weighted root mean
+// square of the residuals, and its close bias is zero.  If every game is at a neutral site, the home bias is zero.
+func FitRatings(games map[string]*Game, teams map[string]*Team, opts RatingsOptions) (*GaussianSpreadModel, error) {
+	if opts.Ridge < 0 {
+		return nil, fmt.Errorf("ridge penalty must not be negative, got %f", opts.Ridge)
+	}
+	completed := completedGames(games)
+	if len(completed) == 0 {
+		return nil, fmt.Errorf("no completed games to fit")
+	}
+
+	// Parameters are the team ratings, in order of first appearance, followed by the home bias.
+	index := make(map[string]int)
+	order := make([]*Team, 0)
+	for _, g := range completed {
+		for _, ref := range []string{g.HomeTeam.ID, g.AwayTeam.ID} {
+			if _, ok := index[ref]; ok {
+				continue
+			}
+			t, ok := teams[ref]
+			if !ok {
+				return nil, fmt.Errorf("team '%s' not found", ref)
+			}
+			index[ref] = len(order)
+			order = append(order, t)
+		}
+	}
+	nTeams := len(order)
+	hb := nTeams
+
+	// Accumulate the normal equations of the weighted least squares problem.
+	a := make([][]float64, nTeams+1)
+	for i := range a {
+		a[i] = make([]float64, nTeams+1)
+	}
+	b := make([]float64, nTeams+1)
+	weights := recencyWeights(completed, opts.HalfLife)
+	for k, g := range completed {
+		w := weights[k]
+		x := map[int]float64{index[g.HomeTeam.ID]: 1, index[g.AwayTeam.ID]: -1}
+		if !g.NeutralSite {
+			x[hb] = 1
+		}
+		for i, xi := range x {
+			b[i] += w * xi * g.margin()
+			for j, xj := range x {
+				a[i][j] += w * xi * xj
+			}
+		}
+	}
+	// The sum-to-zero constraint is added as an observation that the ratings sum to zero.  It does not change the fit, because
+	// adding a constant to every rating does not change any predicted margin.
+	for i := 0; i < nTeams; i++ {
+		a[i][i] += opts.Ridge
+		for j := 0; j < nTeams; j++ {
+			a[i][j]++
+		}
+	}
+	if a[hb][hb] == 0 {
+		// Every game is at a neutral site, so fix the home bias at zero.
+		a[hb][hb] = 1
+	}
+
+	x, err := solveLinear(a, b)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fit ratings: %v", err)
+	}
+
+	ratings := make(map[*Team]float64, nTeams)
+	for i, t := range order {
+		ratings[t] = x[i]
+	}
+	homeBias := x[hb]
+
+	sse, sw := 0., 0.
+	for k, g := range completed {
+		pred := x[index[g.HomeTeam.ID]] - x[index[g.AwayTeam.ID]]
+		if !g.NeutralSite {
+			pred += homeBias
+		}
+		r := g.margin() - pred
+		sse += weights[k] * r * r
+		sw += weights[k]
+	}
+	stdDev := math.Sqrt(sse / sw)
+	if stdDev == 0 {
+		return nil, fmt.Errorf("games fit perfectly, so standard deviation cannot be estimated")
+	}
+
+	return NewGaussianSpreadModel(ratings, stdDev, homeBias, 0.), nil
+}
+
+// solveLinear solves the square system a x = b by Gaussian elimination with partial pivoting.  Both a and b are overwritten.
+func solveLinear(a [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, fmt.Errorf("system is singular")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		for row := col + 1; row < n; row++ {
+			f := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= f * a[col][k]
+			}
+			b[row] -= f * b[col]
+		}
+	}
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		s := b[row]
+		for k := row + 1; k < n; k++ {
+			s -= a[row][k] * x[k]
+		}
+		x[row] = s / a[row][row]
+	}
+	return x, nil
+}
@@ -0,0 +1,118 @@
+package pickem
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func completedGame(home, away string, homePoints, awayPoints int, neutral bool, start time.Time) *Game {
+	return &Game{
+		HomeTeam:    detachedRef(TeamsCollection, home),
+		AwayTeam:    detachedRef(TeamsCollection, away),
+		HomePoints:  &homePoints,
+		AwayPoints:  &awayPoints,
+		NeutralSite: neutral,
+		StartTime:   start,
+	}
+}
+
+func TestFitRatings(t *testing.T) {
+	truth := map[string]float64{"A": 6, "B": 2, "C": -1, "D": -7}
+	teams := make(map[string]*Team)
+	for name := range truth {
+		teams[name] = fakeTeam(name)
+	}
+	start := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	games := make(map[string]*Game)
+	names := []string{"A", "B", "C", "D"}
+	n := 0
+	for _, home := range names {
+		for _, away := range names {
+			if home == away {
+				continue
+			}
+			for _, noise := range []int{-2, 2} {
+				margin := int(truth[home]-truth[away]) + 3 + noise
+				games[fmt.Sprintf("%03d", n)] = completedGame(home, away, 30+margin, 30, false, start.Add(time.Duration(n)*time.Hour))
+				n++
+			}
+		}
+	}
+	games["unplayed"] = &Game{HomeTeam: detachedRef(TeamsCollection, "A"), AwayTeam: detachedRef(TeamsCollection, "E")}
+
+	model, err := FitRatings(games, teams, RatingsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range truth {
+		if got := model.ratings[teams[name]]; math.Abs(got-want) > 1e-9 {
+			t.Errorf("rating of %s = %v, want %v", name, got, want)
+		}
+	}
+	if math.Abs(model.homeBias-3) > 1e-9 {
+		t.Errorf("homeBias = %v, want 3", model.homeBias)
+	}
+	if math.Abs(model.dist.Sigma-2) > 1e-9 {
+		t.Errorf("stdDev = %v, want 2", model.dist.Sigma)
+	}
+
+	ridge, err := FitRatings(games, teams, RatingsOptions{Ridge: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range truth {
+		if got := ridge.ratings[teams[name]]; math.Abs(got) >= math.Abs(want) {
+			t.Errorf("ridge rating of %s = %v, want shrunk from %v", name, got, want)
+		}
+	}
+
+	// A recent upset outweighs an old blowout when the half-life is short.
+	series := map[string]*Game{
+		"old":    completedGame("A", "B", 40, 0, true, start),
+		"recent": completedGame("A", "B", 0, 10, true, start.Add(30*24*time.Hour)),
+	}
+	even, err := FitRatings(series, teams, RatingsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := even.ratings[teams["A"]] - even.ratings[teams["B"]]; math.Abs(got-15) > 1e-9 {
+		t.Errorf("unweighted rating difference = %v, want 15", got)
+	}
+	recent, err := FitRatings(series, teams, RatingsOptions{HalfLife: 24 * time.Hour, Ridge: 1e-6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := recent.ratings[teams["A"]] - recent.ratings[teams["B"]]; got > -9.9 {
+		t.Errorf("weighted rating difference = %v, want about -10", got)
+	}
+}
+
+func TestFitRatings_Errors(t *testing.T) {
+	teams := map[string]*Team{"A": fakeTeam("A"), "B": fakeTeam("B")}
+	start := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		games map[string]*Game
+		opts  RatingsOptions
+	}{
+		{name: "no games", games: map[string]*Game{}},
+		{name: "unplayed", games: map[string]*Game{"1": {HomeTeam: detachedRef(TeamsCollection, "A"), AwayTeam: detachedRef(TeamsCollection, "B")}}},
+		{name: "unknown team", games: map[string]*Game{"1": completedGame("A", "Z", 1, 0, false, start)}},
+		{name: "negative ridge", games: map[string]*Game{"1": completedGame("A", "B", 1, 0, false, start)}, opts: RatingsOptions{Ridge: -1}},
+		{name: "perfect fit", games: map[string]*Game{"1": completedGame("A", "B", 1, 0, true, start)}},
+		{name: "home bias undetermined", games: map[string]*Game{
+			"1": completedGame("A", "B", 3, 0, false, start),
+			"2": completedGame("A", "B", 1, 0, false, start),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FitRatings(tt.games, teams, tt.opts); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
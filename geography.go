@@ -0,0 +1,138 @@
+package pickem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// earthRadius is the mean radius of the Earth in miles.
+const earthRadius = 3958.8
+
+// Distance returns the great-circle distance in miles between two Venues, computed from the latitude and longitude (in degrees)
+// of each Venue's LatLonAlt.
+func (v *Venue) Distance(other *Venue) (float64, error) {
+	if len(v.LatLonAlt) < 2 {
+		return 0., fmt.Errorf("venue '%s' has no coordinates", v.Name)
+	}
+	if len(other.LatLonAlt) < 2 {
+		return 0., fmt.Errorf("venue '%s' has no coordinates", other.Name)
+	}
+	lat1, lon1 := v.LatLonAlt[0]*math.Pi/180, v.LatLonAlt[1]*math.Pi/180
+	lat2, lon2 := other.LatLonAlt[0]*math.Pi/180, other.LatLonAlt[1]*math.Pi/180
+
+	// Haversine formula, which is well-conditioned for small distances.
+	sinLat := math.Sin((lat2 - lat1) / 2)
+	sinLon := math.Sin((lon2 - lon1) / 2)
+	a := sinLat*sinLat + math.Cos(lat1)*math.Cos(lat2)*sinLon*sinLon
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a))), nil
+}
+
+// neutralSiteTolerance is the difference in miles within which a neutral site is considered equally far from two venues.
+const neutralSiteTolerance = .1
+
+// NeutralSiteLocation classifies a neutral site relative to the first of two teams by comparing the great-circle distances
+// from each team's home venue to the site.  The site is Near if it is closer to the first team's home venue, Far if it is
+// closer to the second team's home venue, and Neutral if the distances are within a tenth of a mile of each other.
+func NeutralSiteLocation(site, home1, home2 *Venue) (RelativeLocation, error) {
+	d1, err := home1.Distance(site)
+	if err != nil {
+		return Neutral, err
+	}
+	d2, err := home2.Distance(site)
+	if err != nil {
+		return Neutral, err
+	}
+	switch {
+	case d1 < d2-neutralSiteTolerance:
+		return Near, nil
+	case d1 > d2+neutralSiteTolerance:
+		return Far, nil
+	}
+	return Neutral, nil
+}
+
+// venueOrNil looks up a venue by document ID, returning nil if the venue is not in the Store.
+func venueOrNil(ctx context.Context, s Store, id string) (*Venue, error) {
+	v, err := s.Venue(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return v, err
+}
+
+// GameLocation returns the location of a Game relative to the given team, which must be the Game's home or away team.
+//
+// Games not at a neutral site are Home or Away.  Games at a neutral site are classified as Near, Far, or Neutral by
+// NeutralSiteLocation.  If the game's venue or either team's home venue is missing or has no coordinates, the game is Neutral.
+func GameLocation(ctx context.Context, s Store, g *Game, team *Team) (RelativeLocation, error) {
+	opponent := func(id string) (*Team, error) { return s.Team(ctx, id) }
+	venue := func(id string) (*Venue, error) { return venueOrNil(ctx, s, id) }
+	return gameLocation(g, team, opponent, venue)
+}
+
+// gameLocation implements GameLocation with functions that look up teams and venues by document ID.
+// The venue function returns nil if the venue does not exist.
+func gameLocation(g *Game, team *Team, teamByID func(string) (*Team, error), venueByID func(string) (*Venue, error)) (RelativeLocation, error) {
+	var opponentRef string
+	sign := RelativeLocation(1)
+	switch team.SchoolName {
+	case g.HomeTeam.ID:
+		opponentRef = g.AwayTeam.ID
+	case g.AwayTeam.ID:
+		opponentRef = g.HomeTeam.ID
+		sign = -1
+	default:
+		return Neutral, fmt.Errorf("team '%s' does not play in game", team.SchoolName)
+	}
+	if !g.NeutralSite {
+		return sign * Home, nil
+	}
+
+	opponent, err := teamByID(opponentRef)
+	if err != nil {
+		return Neutral, err
+	}
+	home, away := team, opponent
+	if sign < 0 {
+		home, away = opponent, team
+	}
+	loc, err := homeLocation(g, home, away, venueByID)
+	return sign * loc, err
+}
+
+// homeLocation returns the location of a Game relative to its home team, as GameLocation does, given both of its teams.
+// The venue function looks up venues by document ID and returns nil if the venue does not exist.
+func homeLocation(g *Game, home, away *Team, venueByID func(string) (*Venue, error)) (RelativeLocation, error) {
+	if !g.NeutralSite {
+		return Home, nil
+	}
+	if g.Venue == nil {
+		return Neutral, nil
+	}
+	site, err := venueByID(g.Venue.ID)
+	if err != nil || site == nil {
+		return Neutral, err
+	}
+	var home1, home2 *Venue
+	if home.HomeVenue != nil {
+		if home1, err = venueByID(home.HomeVenue.ID); err != nil {
+			return Neutral, err
+		}
+	}
+	if away.HomeVenue != nil {
+		if home2, err = venueByID(away.HomeVenue.ID); err != nil {
+			return Neutral, err
+		}
+	}
+	if home1 == nil || home2 == nil {
+		return Neutral, nil
+	}
+	loc, err := NeutralSiteLocation(site, home1, home2)
+	if err != nil {
+		// Missing coordinates are treated the same as a missing venue.
+		return Neutral, nil
+	}
+	return loc, nil
+}
@@ -0,0 +1,106 @@
+package pickem
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestVenue_Distance(t *testing.T) {
+	jfk := &Venue{Name: "JFK", LatLonAlt: []float64{40.6413, -73.7781, 4}}
+	lax := &Venue{Name: "LAX", LatLonAlt: []float64{33.9416, -118.4085, 38}}
+	if d, err := jfk.Distance(lax); err != nil || math.Abs(d-2470) > 10 {
+		t.Errorf("expected about 2470 miles, got %v, %v", d, err)
+	}
+	if d, err := lax.Distance(jfk); err != nil || math.Abs(d-2470) > 10 {
+		t.Errorf("expected distance to be symmetric, got %v, %v", d, err)
+	}
+	if d, err := jfk.Distance(jfk); err != nil || d != 0 {
+		t.Errorf("expected zero distance to self, got %v, %v", d, err)
+	}
+	if _, err := jfk.Distance(&Venue{Name: "Nowhere"}); err == nil {
+		t.Errorf("expected error for venue without coordinates")
+	}
+}
+
+func TestNeutralSiteLocation(t *testing.T) {
+	west := &Venue{LatLonAlt: []float64{40, -100}}
+	east := &Venue{LatLonAlt: []float64{40, -80}}
+	// The site at 41.419215 is equally far from these to within a millionth of a mile, but not exactly.
+	southBend := &Venue{LatLonAlt: []float64{41.6984, -86.2339}}
+	stateCollege := &Venue{LatLonAlt: []float64{40.8122, -77.8561}}
+	tests := []struct {
+		name         string
+		site         *Venue
+		home1, home2 *Venue
+		want         RelativeLocation
+	}{
+		{"near", &Venue{LatLonAlt: []float64{41, -98}}, west, east, Near},
+		{"far", &Venue{LatLonAlt: []float64{39, -83}}, west, east, Far},
+		{"halfway", &Venue{LatLonAlt: []float64{30, -90}}, west, east, Neutral},
+		{"within tolerance", &Venue{LatLonAlt: []float64{30, -90.001}}, west, east, Neutral},
+		{"outside tolerance", &Venue{LatLonAlt: []float64{30, -90.01}}, west, east, Near},
+		{"equidistant", &Venue{LatLonAlt: []float64{41.419215, -82}}, southBend, stateCollege, Neutral},
+		{"equidistant swapped", &Venue{LatLonAlt: []float64{41.419215, -82}}, stateCollege, southBend, Neutral},
+		{"not equidistant", &Venue{LatLonAlt: []float64{41.43, -82}}, southBend, stateCollege, Near},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NeutralSiteLocation(tt.site, tt.home1, tt.home2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("NeutralSiteLocation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameLocation(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+	games, err := s.Games(ctx, GameQuery{Season: 2019})
+	if err != nil {
+		t.Fatal(err)
+	}
+	team := func(id string) *Team {
+		tm, err := s.Team(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+	games["neutral without home venue"] = &Game{HomeTeam: s.Ref(TeamsCollection, "Alpha"), AwayTeam: s.Ref(TeamsCollection, "Delta East"), NeutralSite: true, Venue: s.Ref(VenuesCollection, "99")}
+	games["neutral without venue"] = &Game{HomeTeam: s.Ref(TeamsCollection, "Alpha"), AwayTeam: s.Ref(TeamsCollection, "Gamma"), NeutralSite: true}
+	games["unknown venue"] = &Game{HomeTeam: s.Ref(TeamsCollection, "Alpha"), AwayTeam: s.Ref(TeamsCollection, "Beta"), NeutralSite: true, Venue: s.Ref(VenuesCollection, "404")}
+
+	tests := []struct {
+		game    string
+		team    string
+		want    RelativeLocation
+		wantErr bool
+	}{
+		{game: "101", team: "Alpha", want: Home},
+		{game: "101", team: "Beta", want: Away},
+		{game: "102", team: "Alpha", want: Near},
+		{game: "102", team: "Gamma", want: Far},
+		{game: "neutral without home venue", team: "Alpha", want: Neutral},
+		{game: "neutral without home venue", team: "Delta East", want: Neutral},
+		{game: "neutral without venue", team: "Alpha", want: Neutral},
+		{game: "neutral without venue", team: "Gamma", want: Neutral},
+		{game: "unknown venue", team: "Beta", want: Neutral},
+		{game: "101", team: "Gamma", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.game+" "+tt.team, func(t *testing.T) {
+			got, err := GameLocation(ctx, s, games[tt.game], team(tt.team))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GameLocation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("GameLocation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ go 1.13
 require (
 	cloud.google.com/go/firestore v1.0.0
 	github.com/atgjack/prob v0.0.0-20161220081030-6cfd5d401186
-	github.com/segmentio/fasthash v1.0.1
+	go.etcd.io/bbolt v1.3.5
 	google.golang.org/api v0.9.0
+	google.golang.org/grpc v1.21.1
 )
@@ -1,6 +1,8 @@
 package pickem
 
 import (
+	"context"
+	"fmt"
 	"math/big"
 	"testing"
 )
@@ -80,6 +82,137 @@ func TestIdenticalPermutor(t *testing.T) {
 	}
 }
 
+func TestIdenticalPermutor_Distinct(t *testing.T) {
+	p := NewIdenticalPermutor(4, 3, 0, 3)
+	seen := make(map[string]bool)
+	n := 0
+	for test := range p.Iterator() {
+		key := fmt.Sprint(test)
+		if seen[key] {
+			t.Fatalf("permutation %v produced twice", test)
+		}
+		seen[key] = true
+		n++
+	}
+	if nop := p.NumberOfPermutations(); nop.Cmp(big.NewInt(int64(n))) != 0 {
+		t.Errorf("expected %v, got %v", nop, n)
+	}
+}
+
+func TestIdenticalPermutor_Rank(t *testing.T) {
+	p := NewIdenticalPermutor(2, 0, 3, 1)
+
+	// Ranks follow the iterator.
+	rank := new(big.Int)
+	for test := range p.Iterator() {
+		if got, err := p.Rank(test); err != nil || got.Cmp(rank) != 0 {
+			t.Errorf("Rank(%v) expected %v, got %v (%v)", test, rank, got, err)
+		}
+		if got, err := p.Permutation(rank); err != nil || !check(got, test) {
+			t.Errorf("Permutation(%v) expected %v, got %v (%v)", rank, test, got, err)
+		}
+		rank.Add(rank, big.NewInt(1))
+	}
+	if rank.Cmp(p.NumberOfPermutations()) != 0 {
+		t.Errorf("expected %v permutations, got %v", p.NumberOfPermutations(), rank)
+	}
+
+	// Large sets
+	p2 := NewIdenticalPermutor(5, 5, 5, 5, 5, 5)
+	last := new(big.Int).Sub(p2.NumberOfPermutations(), big.NewInt(1))
+	perm, err := p2.Permutation(last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm[0] != 5 || perm[len(perm)-1] != 0 {
+		t.Errorf("expected last permutation, got %v", perm)
+	}
+	if got, err := p2.Rank(perm); err != nil || got.Cmp(last) != 0 {
+		t.Errorf("expected %v, got %v (%v)", last, got, err)
+	}
+
+	if _, err := p.Rank([]int{0, 0, 0, 2, 2, 3}); err == nil {
+		t.Errorf("expected error for a permutation of a different set")
+	}
+	if _, err := p.Permutation(p.NumberOfPermutations()); err == nil {
+		t.Errorf("expected error for rank out of range")
+	}
+}
+
+func TestIdenticalPermutor_PermutationsFrom(t *testing.T) {
+	p := NewIdenticalPermutor(2, 0, 3, 1)
+	start := big.NewInt(11)
+	itr, err := p.PermutationsFrom(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rank := new(big.Int).Set(start)
+	for itr.Next() {
+		if got, err := p.Rank(itr.Permutation()); err != nil || got.Cmp(rank) != 0 {
+			t.Errorf("expected rank %v, got %v (%v)", rank, got, err)
+		}
+		rank.Add(rank, big.NewInt(1))
+	}
+	if rank.Cmp(p.NumberOfPermutations()) != 0 {
+		t.Errorf("expected to finish at rank %v, got %v", p.NumberOfPermutations(), rank)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	itr, err = p.PermutationsFromContext(ctx, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if itr.Next() || itr.Err() != context.Canceled {
+		t.Errorf("expected cancelled iterator, got %v", itr.Err())
+	}
+	if _, err := p.PermutationsFrom(big.NewInt(-1)); err == nil {
+		t.Errorf("expected error for negative rank")
+	}
+}
+
+func TestIdenticalPermutor_Permutations(t *testing.T) {
+	p := NewIdenticalPermutor(3, 2, 1)
+
+	// Same order as the channel
+	itr := p.Permutations()
+	n := 0
+	for test := range p.Iterator() {
+		if !itr.Next() {
+			t.Fatalf("expected %v, got end of iteration", test)
+		}
+		if !check(test, itr.Permutation()) {
+			t.Errorf("expected %v, got %v", test, itr.Permutation())
+		}
+		n++
+	}
+	if itr.Next() {
+		t.Errorf("expected end of iteration, got %v", itr.Permutation())
+	}
+	if itr.Next() {
+		t.Errorf("expected iteration to stay ended")
+	}
+	if itr.Err() != nil {
+		t.Errorf("expected no error, got %v", itr.Err())
+	}
+	if p.NumberOfPermutations().Cmp(big.NewInt(int64(n))) != 0 {
+		t.Errorf("expected %v, got %v", p.NumberOfPermutations(), n)
+	}
+
+	// Cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	itr = p.PermutationsContext(ctx)
+	if !itr.Next() {
+		t.Fatalf("expected first permutation")
+	}
+	cancel()
+	for itr.Next() {
+	}
+	if itr.Err() != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, itr.Err())
+	}
+}
+
 func BenchmarkIdenticalPermutor10(b *testing.B) {
 	p := NewIdenticalPermutor(4, 3, 3)
 	b.ResetTimer()
@@ -91,3 +224,15 @@ func BenchmarkIdenticalPermutor10(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkIdenticalPermutorPermutations10(b *testing.B) {
+	p := NewIdenticalPermutor(4, 3, 3)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		itr := p.Permutations()
+		for itr.Next() {
+			// Count them all!
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package pickem
 
 import (
+	"context"
 	"math/big"
 	"testing"
 )
@@ -66,6 +67,114 @@ func TestIndexPermutor(t *testing.T) {
 	}
 }
 
+func TestIndexPermutor_Rank(t *testing.T) {
+	p := NewIndexPermutor(5)
+
+	// Lexicographic order
+	perm := []int{0, 1, 2, 3, 4}
+	rank := new(big.Int)
+	for ok := true; ok; ok = nextPermutation(perm) {
+		if got, err := p.Rank(perm); err != nil || got.Cmp(rank) != 0 {
+			t.Errorf("Rank(%v) expected %v, got %v (%v)", perm, rank, got, err)
+		}
+		if got, err := p.Permutation(rank); err != nil || !check(got, perm) {
+			t.Errorf("Permutation(%v) expected %v, got %v (%v)", rank, perm, got, err)
+		}
+		rank.Add(rank, big.NewInt(1))
+	}
+
+	// Every permutation from the iterator has a distinct rank
+	seen := make(map[string]bool)
+	for test := range p.Iterator() {
+		r, err := p.Rank(test)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[r.String()] = true
+	}
+	if len(seen) != 120 {
+		t.Errorf("expected 120 ranks, got %v", len(seen))
+	}
+
+	if _, err := p.Permutation(p.NumberOfPermutations()); err == nil {
+		t.Errorf("expected error for rank out of range")
+	}
+	if _, err := p.Permutation(big.NewInt(-1)); err == nil {
+		t.Errorf("expected error for negative rank")
+	}
+	if _, err := p.Rank([]int{0, 1, 2, 3}); err == nil {
+		t.Errorf("expected error for short permutation")
+	}
+	if _, err := p.Rank([]int{0, 1, 2, 3, 3}); err == nil {
+		t.Errorf("expected error for repeated element")
+	}
+}
+
+func TestIndexPermutor_PermutationsFrom(t *testing.T) {
+	p := NewIndexPermutor(5)
+	start := big.NewInt(37)
+	itr, err := p.PermutationsFrom(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rank := new(big.Int).Set(start)
+	for itr.Next() {
+		if got, err := p.Rank(itr.Permutation()); err != nil || got.Cmp(rank) != 0 {
+			t.Errorf("expected rank %v, got %v (%v)", rank, got, err)
+		}
+		rank.Add(rank, big.NewInt(1))
+	}
+	if rank.Cmp(p.NumberOfPermutations()) != 0 {
+		t.Errorf("expected to finish at rank %v, got %v", p.NumberOfPermutations(), rank)
+	}
+
+	if _, err := p.PermutationsFrom(p.NumberOfPermutations()); err == nil {
+		t.Errorf("expected error for rank out of range")
+	}
+}
+
+func TestIndexPermutor_Permutations(t *testing.T) {
+	p := NewIndexPermutor(6)
+
+	// Same order as the channel
+	itr := p.Permutations()
+	n := 0
+	for test := range p.Iterator() {
+		if !itr.Next() {
+			t.Fatalf("expected %v, got end of iteration", test)
+		}
+		if !check(test, itr.Permutation()) {
+			t.Errorf("expected %v, got %v", test, itr.Permutation())
+		}
+		n++
+	}
+	if itr.Next() {
+		t.Errorf("expected end of iteration, got %v", itr.Permutation())
+	}
+	if itr.Next() {
+		t.Errorf("expected iteration to stay ended")
+	}
+	if itr.Err() != nil {
+		t.Errorf("expected no error, got %v", itr.Err())
+	}
+	if p.NumberOfPermutations().Cmp(big.NewInt(int64(n))) != 0 {
+		t.Errorf("expected %v, got %v", p.NumberOfPermutations(), n)
+	}
+
+	// Cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	itr = p.PermutationsContext(ctx)
+	if !itr.Next() {
+		t.Fatalf("expected first permutation")
+	}
+	cancel()
+	for itr.Next() {
+	}
+	if itr.Err() != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, itr.Err())
+	}
+}
+
 func BenchmarkPermuteAll10(b *testing.B) {
 	p := NewIndexPermutor(10)
 	b.ResetTimer()
@@ -77,3 +186,15 @@ func BenchmarkPermuteAll10(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkIndexPermutorPermutations10(b *testing.B) {
+	p := NewIndexPermutor(10)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		itr := p.Permutations()
+		for itr.Next() {
+			// Count them all!
+		}
+	}
+}
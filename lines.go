@@ -0,0 +1,231 @@
+package pickem
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Lines holds the spreads predicted by several models for a slate of games, as read from a file of prediction lines.
+type Lines struct {
+	// Matchups are the games in the order they were read, with the home team first and a location of Neutral, because the
+	// lines already include the advantage of the home team.  Predicting them with a location of Home would add the model's
+	// home bias a second time.
+	Matchups []*Matchup
+	// Models holds one LookupModel per model, keyed by model name.
+	Models map[string]*LookupModel
+}
+
+// A LineRecord is one game of a JSON lines file.  Lines are spreads in favor of the home team, keyed by model name.
+// A model with no line for the game is left out or given a null line.  A model named more than once is an error.
+type LineRecord struct {
+	Home  string              `json:"home"`
+	Road  string              `json:"road"`
+	Lines map[string]*float64 `json:"lines"`
+}
+
+// UnmarshalJSON decodes a LineRecord, returning an error if a model has more than one line, which the standard decoder would
+// silently overwrite.
+func (r *LineRecord) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Home  string          `json:"home"`
+		Road  string          `json:"road"`
+		Lines json.RawMessage `json:"lines"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.Home, r.Road, r.Lines = raw.Home, raw.Road, nil
+	if len(raw.Lines) == 0 || string(raw.Lines) == "null" {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw.Lines))
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("lines must be an object, got %v", tok)
+	}
+	r.Lines = make(map[string]*float64)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		model := tok.(string)
+		if _, ok := r.Lines[model]; ok {
+			return fmt.Errorf("model '%s' has more than one line", model)
+		}
+		var line *float64
+		if err := dec.Decode(&line); err != nil {
+			return fmt.Errorf("line of model '%s': %v", model, err)
+		}
+		r.Lines[model] = line
+	}
+	return nil
+}
+
+// lineBuilder resolves team names and collects the lines of each model.
+type lineBuilder struct {
+	ctx    context.Context
+	store  Store
+	byName map[string]*Team
+	byID   map[string]*Team
+
+	matchups []*Matchup
+	homes    map[string][]*Team
+	roads    map[string][]*Team
+	spreads  map[string][]float64
+}
+
+func newLineBuilder(ctx context.Context, s Store) *lineBuilder {
+	return &lineBuilder{
+		ctx:     ctx,
+		store:   s,
+		byName:  make(map[string]*Team),
+		byID:    make(map[string]*Team),
+		homes:   make(map[string][]*Team),
+		roads:   make(map[string][]*Team),
+		spreads: make(map[string][]float64),
+	}
+}
+
+// team looks up a team by name with LookupTeam.  Every name that resolves to the same team document gets the same *Team,
+// so the Matchups and Models agree on the teams.
+func (b *lineBuilder) team(name string) (*Team, error) {
+	if t, ok := b.byName[name]; ok {
+		return t, nil
+	}
+	t, err := LookupTeam(b.ctx, b.store, name)
+	if err != nil {
+		return nil, err
+	}
+	if cached, ok := b.byID[t.SchoolName]; ok {
+		t = cached
+	} else {
+		b.byID[t.SchoolName] = t
+	}
+	b.byName[name] = t
+	return t, nil
+}
+
+// add records a game and the lines of the models that predicted it.
+func (b *lineBuilder) add(home, road string, lines map[string]*float64) error {
+	t1, err := b.team(home)
+	if err != nil {
+		return err
+	}
+	t2, err := b.team(road)
+	if err != nil {
+		return err
+	}
+	b.matchups = append(b.matchups, NewMatchup(t1, t2, Neutral))
+	for model, line := range lines {
+		if _, ok := b.spreads[model]; !ok {
+			b.spreads[model] = make([]float64, 0)
+		}
+		if line == nil {
+			continue
+		}
+		b.homes[model] = append(b.homes[model], t1)
+		b.roads[model] = append(b.roads[model], t2)
+		b.spreads[model] = append(b.spreads[model], *line)
+	}
+	return nil
+}
+
+func (b *lineBuilder) build(c Calibration) *Lines {
+	models := make(map[string]*LookupModel, len(b.spreads))
+	for model, spreads := range b.spreads {
+		models[model] = NewLookupModel(b.homes[model], b.roads[model], spreads, c.StdDev, c.HomeBias, c.CloseBias)
+	}
+	return &Lines{Matchups: b.matchups, Models: models}
+}
+
+// ReadLinesCSV reads prediction lines from CSV.  The header row names the columns:  the home team, the road team, then one
+// column per model, each named once.  Each following row is a game, with the spread in favor of the home team predicted by each model.
+// Empty or non-numeric lines mean the model did not predict the game.
+//
+// Team names are resolved with LookupTeam.  Each model gets a LookupModel with the calibration's standard deviation and biases.
+func ReadLinesCSV(ctx context.Context, s Store, r io.Reader, c Calibration) (*Lines, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read lines header: %v", err)
+	}
+	if len(header) < 2 {
+		return nil, fmt.Errorf("lines header must have home and road columns, got %d columns", len(header))
+	}
+	models := header[2:]
+	seen := make(map[string]bool, len(models))
+	for _, model := range models {
+		if seen[model] {
+			return nil, fmt.Errorf("model '%s' has more than one column", model)
+		}
+		seen[model] = true
+	}
+
+	b := newLineBuilder(ctx, s)
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read lines row %d: %v", row, err)
+		}
+		lines := make(map[string]*float64, len(models))
+		for i, model := range models {
+			lines[model] = nil
+			if val, err := strconv.ParseFloat(strings.TrimSpace(record[i+2]), 64); err == nil {
+				lines[model] = &val
+			}
+		}
+		if err := b.add(record[0], record[1], lines); err != nil {
+			return nil, fmt.Errorf("lines row %d: %w", row, err)
+		}
+	}
+	return b.build(c), nil
+}
+
+// ReadLinesJSON reads prediction lines from a JSON array of LineRecords.
+//
+// Team names are resolved with LookupTeam.  Each model gets a LookupModel with the calibration's standard deviation and biases.
+func ReadLinesJSON(ctx context.Context, s Store, r io.Reader, c Calibration) (*Lines, error) {
+	var records []LineRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, fmt.Errorf("cannot decode lines: %v", err)
+	}
+
+	b := newLineBuilder(ctx, s)
+	for i, record := range records {
+		if err := b.add(record.Home, record.Road, record.Lines); err != nil {
+			return nil, fmt.Errorf("lines record %d: %w", i, err)
+		}
+	}
+	return b.build(c), nil
+}
+
+// LoadLinesFile reads prediction lines from a local file, as CSV if the file name ends in ".csv" and as JSON if it ends in ".json".
+func LoadLinesFile(ctx context.Context, s Store, path string, c Calibration) (*Lines, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ReadLinesCSV(ctx, s, f, c)
+	case ".json":
+		return ReadLinesJSON(ctx, s, f, c)
+	}
+	return nil, fmt.Errorf("unknown lines file type '%s'", filepath.Ext(path))
+}
@@ -0,0 +1,103 @@
+package pickem
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestLoadLinesFile(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+	c := Calibration{StdDev: 10, HomeBias: 2, CloseBias: 1}
+
+	for _, path := range []string{"testdata/lines.csv", "testdata/lines.json"} {
+		t.Run(path, func(t *testing.T) {
+			lines, err := LoadLinesFile(ctx, s, path, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(lines.Matchups) != 3 {
+				t.Fatalf("expected 3 matchups, got %d", len(lines.Matchups))
+			}
+			alpha, beta, gamma := lines.Matchups[0].Team1, lines.Matchups[0].Team2, lines.Matchups[1].Team2
+			if alpha.SchoolName != "Alpha" || beta.SchoolName != "Beta" || gamma.SchoolName != "Gamma" {
+				t.Errorf("expected Alpha, Beta, Gamma, got %s, %s, %s", alpha.SchoolName, beta.SchoolName, gamma.SchoolName)
+			}
+			if lines.Matchups[1].Team1 != beta || lines.Matchups[2].Team1 != alpha || lines.Matchups[2].Team2 != gamma {
+				t.Errorf("expected teams with different names to resolve to the same *Team")
+			}
+			if len(lines.Models) != 3 {
+				t.Fatalf("expected 3 models, got %d", len(lines.Models))
+			}
+
+			// The lines already include the advantage of the home team, so the home bias is not added again.
+			if _, spread, err := lines.Models["sagarin"].Predict(*lines.Matchups[0]); err != nil || spread != 3.5 {
+				t.Errorf("sagarin %s v %s: spread = %v (%v), want 3.5", alpha.SchoolName, beta.SchoolName, spread, err)
+			}
+
+			tests := []struct {
+				model string
+				mu    Matchup
+				want  float64
+				ok    bool
+			}{
+				{"sagarin", Matchup{Team1: alpha, Team2: beta, Location: Neutral}, 3.5, true},
+				{"sagarin", Matchup{Team1: gamma, Team2: beta, Location: Neutral}, 1, true},
+				{"sagarin", Matchup{Team1: alpha, Team2: gamma, Location: Home}, 9, true},
+				{"massey", Matchup{Team1: alpha, Team2: gamma, Location: Neutral}, 8.5, true},
+				{"massey", Matchup{Team1: beta, Team2: gamma, Location: Neutral}, 0, false},
+				{"line", Matchup{Team1: beta, Team2: alpha, Location: Neutral}, -3, true},
+				{"line", Matchup{Team1: alpha, Team2: gamma, Location: Neutral}, 0, false},
+			}
+			for _, tt := range tests {
+				_, spread, err := lines.Models[tt.model].Predict(tt.mu)
+				if (err == nil) != tt.ok {
+					t.Errorf("%s %s v %s: error = %v, want ok %v", tt.model, tt.mu.Team1.SchoolName, tt.mu.Team2.SchoolName, err, tt.ok)
+					continue
+				}
+				if tt.ok && spread != tt.want {
+					t.Errorf("%s %s v %s: spread = %v, want %v", tt.model, tt.mu.Team1.SchoolName, tt.mu.Team2.SchoolName, spread, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadLines_Errors(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+	c := Calibration{StdDev: 10}
+
+	csvs := map[string]string{
+		"empty":        "",
+		"short header": "home\n",
+		"unknown team": "home,road,m\nAlpha,Omega,1\n",
+		"ambiguous":    "home,road,m\nAlpha,DEL,1\n",
+		"ragged":       "home,road,m\nAlpha,Beta,1,2\n",
+		"duplicate":    "home,road,m,n,m\nAlpha,Beta,1,2,3\n",
+	}
+	for name, in := range csvs {
+		if _, err := ReadLinesCSV(ctx, s, strings.NewReader(in), c); err == nil {
+			t.Errorf("CSV %s: expected error", name)
+		}
+	}
+	if _, err := ReadLinesJSON(ctx, s, strings.NewReader(`{"home": "Alpha"}`), c); err == nil {
+		t.Errorf("expected error for JSON object")
+	}
+	if _, err := ReadLinesJSON(ctx, s, strings.NewReader(`[{"home": "Alpha", "road": "Omega"}]`), c); err == nil {
+		t.Errorf("expected error for unknown JSON team")
+	}
+	if _, err := ReadLinesJSON(ctx, s, strings.NewReader(`[{"home": "Alpha", "road": "Beta", "lines": {"m": 1, "n": 2, "m": 3}}]`), c); err == nil {
+		t.Errorf("expected error for duplicate JSON model")
+	}
+	if _, err := ReadLinesJSON(ctx, s, strings.NewReader(`[{"home": "Alpha", "road": "Beta", "lines": [1]}]`), c); err == nil {
+		t.Errorf("expected error for JSON lines that are not an object")
+	}
+	if _, err := LoadLinesFile(ctx, s, "testdata/fixtures.txt", c); err == nil {
+		t.Errorf("expected error for missing file")
+	}
+	if _, err := LoadLinesFile(ctx, s, "testdata/lines.txt", c); err == nil || !strings.Contains(err.Error(), "unknown lines file type") {
+		t.Errorf("expected error for unknown file extension, got %v", err)
+	}
+}
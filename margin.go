@@ -0,0 +1,76 @@
+package pickem
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/atgjack/prob"
+)
+
+// A MarginDistribution is the predicted distribution of the first team's margin of victory in a Matchup.
+// A negative margin means the first team loses.
+type MarginDistribution interface {
+	// Mean returns the expected margin, which is the predicted spread.
+	Mean() float64
+	// StdDev returns the standard deviation of the margin about its mean.
+	StdDev() float64
+	// Cdf returns the probability that the margin is at most x.
+	Cdf(x float64) float64
+	// Quantile returns the margin that is not exceeded with probability p.
+	Quantile(p float64) float64
+}
+
+// MarginPredicter is a MatchupPredicter that can also predict the full distribution of the margin of victory.
+type MarginPredicter interface {
+	MatchupPredicter
+	PredictMargin(Matchup) (MarginDistribution, error)
+}
+
+// NormalMargin is a normally distributed margin of victory.
+type NormalMargin struct {
+	prob.Normal
+}
+
+// Quantile returns the margin that is not exceeded with probability p.  It returns -Inf for p == 0, +Inf for p == 1,
+// and NaN for p outside of [0, 1].
+func (d NormalMargin) Quantile(p float64) float64 {
+	return d.Mu + d.Sigma*math.Sqrt2*math.Erfinv(2*p-1)
+}
+
+// CoverProbability returns the probability that the first team wins by more than the line.  A negative line is the number of
+// points the first team can lose by and still cover.
+func CoverProbability(d MarginDistribution, line float64) float64 {
+	return 1 - d.Cdf(line)
+}
+
+// checkMarginMatchup returns an error if either team of the Matchup is missing, because a bye has no margin of victory.
+func checkMarginMatchup(mu Matchup) error {
+	if mu.Team1 == nil || mu.Team2 == nil {
+		return fmt.Errorf("cannot predict the margin of a matchup with a bye")
+	}
+	return nil
+}
+
+// PredictMargin returns the distribution of team1's margin of victory:  normal about the predicted spread with the model's standard deviation.
+func (m *GaussianSpreadModel) PredictMargin(mu Matchup) (MarginDistribution, error) {
+	if err := checkMarginMatchup(mu); err != nil {
+		return nil, err
+	}
+	spread, err := m.spread(mu.Team1, mu.Team2, mu.Location, Conditions{})
+	if err != nil {
+		return nil, err
+	}
+	return NormalMargin{prob.Normal{Mu: spread, Sigma: m.dist.Sigma}}, nil
+}
+
+// PredictMargin returns the distribution of team1's margin of victory:  normal about the predicted spread with the model's standard deviation.
+func (m *LookupModel) PredictMargin(mu Matchup) (MarginDistribution, error) {
+	if err := checkMarginMatchup(mu); err != nil {
+		return nil, err
+	}
+	spread, err := m.spread(mu)
+	if err != nil {
+		return nil, err
+	}
+	return NormalMargin{prob.Normal{Mu: spread, Sigma: m.dist.Sigma}}, nil
+}
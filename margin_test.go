@@ -0,0 +1,55 @@
+package pickem
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalMargin(t *testing.T) {
+	teamA := fakeTeam("A")
+	teamB := fakeTeam("B")
+	models := map[string]MarginPredicter{
+		"gaussian": NewGaussianSpreadModel(map[*Team]float64{teamA: 5, teamB: 1}, 10, 3, 1),
+		"lookup":   NewLookupModel([]*Team{teamA}, []*Team{teamB}, []float64{4}, 10, 3, 1),
+	}
+
+	for name, m := range models {
+		t.Run(name, func(t *testing.T) {
+			prob, spread, err := m.Predict(Matchup{Team1: teamA, Team2: teamB, Location: Home})
+			if err != nil {
+				t.Fatal(err)
+			}
+			d, err := m.PredictMargin(Matchup{Team1: teamA, Team2: teamB, Location: Home})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Mean() != spread {
+				t.Errorf("Mean() = %v, want spread %v", d.Mean(), spread)
+			}
+			if got := CoverProbability(d, 0); math.Abs(got-prob) > 1e-12 {
+				t.Errorf("CoverProbability(0) = %v, want probability of win %v", got, prob)
+			}
+			if got := d.Quantile(.5); math.Abs(got-spread) > 1e-12 {
+				t.Errorf("Quantile(.5) = %v, want %v", got, spread)
+			}
+			for _, p := range []float64{.01, .1, .3, .7, .9, .99} {
+				if got := d.Cdf(d.Quantile(p)); math.Abs(got-p) > 1e-9 {
+					t.Errorf("Cdf(Quantile(%v)) = %v", p, got)
+				}
+			}
+			if got := d.Quantile(.8413447460685429) - spread; math.Abs(got-10) > 1e-6 {
+				t.Errorf("expected one standard deviation above the spread, got %v", got)
+			}
+			if !math.IsInf(d.Quantile(0), -1) || !math.IsInf(d.Quantile(1), 1) || !math.IsNaN(d.Quantile(2)) {
+				t.Errorf("unexpected quantiles at the edges: %v, %v, %v", d.Quantile(0), d.Quantile(1), d.Quantile(2))
+			}
+
+			if _, err := m.PredictMargin(Matchup{Team1: teamA, Team2: nil, Location: Home}); err == nil {
+				t.Errorf("expected error for bye")
+			}
+			if _, err := m.PredictMargin(Matchup{Team1: teamA, Team2: fakeTeam("C"), Location: Home}); err == nil {
+				t.Errorf("expected error for unknown team")
+			}
+		})
+	}
+}
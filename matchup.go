@@ -52,6 +52,9 @@ type Matchup struct {
 	Team1    *Team
 	Team2    *Team
 	Location RelativeLocation
+	// Conditions are the circumstances under which the teams play, with the first team's conditions as Team1.  The zero
+	// Conditions are not adjusted for.
+	Conditions Conditions
 }
 
 // MatchupRef is a representation of a matchup in Firestore.
@@ -61,25 +64,18 @@ type MatchupRef struct {
 	Location RelativeLocation       `json:"location" firestore:"location"`
 }
 
-// GetTo implements FirestoreGetter and fills MatchupRef with primatives.
-func (mr *MatchupRef) GetTo(ctx context.Context, fs *firestore.Client, m *Matchup) error {
-	var t1, t2 Team
-	var ts *firestore.DocumentSnapshot
+// GetTo fills Matchup with the Teams referenced by MatchupRef, looked up from the Store.
+func (mr *MatchupRef) GetTo(ctx context.Context, s Store, m *Matchup) error {
+	var t1, t2 *Team
 	var err error
-	if ts, err = mr.Team1.Get(ctx); err != nil {
+	if t1, err = s.Team(ctx, mr.Team1.ID); err != nil {
 		return err
 	}
-	if err = ts.DataTo(&t1); err != nil {
+	if t2, err = s.Team(ctx, mr.Team2.ID); err != nil {
 		return err
 	}
-	if ts, err = mr.Team2.Get(ctx); err != nil {
-		return err
-	}
-	if err = ts.DataTo(&t2); err != nil {
-		return err
-	}
-	m.Team1 = &t1
-	m.Team2 = &t2
+	m.Team1 = t1
+	m.Team2 = t2
 	m.Location = mr.Location
 	return nil
 }
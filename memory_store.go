@@ -0,0 +1,146 @@
+package pickem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// MemoryStore implements Store in memory.  It is meant for testing and for seeding from fixtures.
+type MemoryStore struct {
+	docStore
+}
+
+// NewMemoryStore makes an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{docStore{&memoryBackend{docs: make(map[string]map[string][]byte)}}}
+}
+
+// Close implements Store.  It does nothing.
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// Fixture holds documents with which to seed a Store, keyed by document ID.
+// Fixtures are encoded as JSON using the struct tags of the documents.  References in fixtures only need an ID, e.g.:
+//
+//	{"games": {"401": {"season": {"ID": "2019"}, "week": 1, "home_team": {"ID": "Ohio State"}, ...}}}
+type Fixture struct {
+	Teams   map[string]*Team   `json:"teams"`
+	Games   map[string]*Game   `json:"games"`
+	Venues  map[string]*Venue  `json:"venues"`
+	Seasons map[string]*Season `json:"seasons"`
+	Players map[string]*Player `json:"players"`
+}
+
+// Load writes the documents in the fixture to the Store, overwriting existing documents.
+func (f *Fixture) Load(ctx context.Context, s Store) error {
+	if err := s.PutTeams(ctx, f.Teams, true); err != nil {
+		return err
+	}
+	if err := s.PutGames(ctx, f.Games, true); err != nil {
+		return err
+	}
+	if err := s.PutVenues(ctx, f.Venues, true); err != nil {
+		return err
+	}
+	if err := s.PutSeasons(ctx, f.Seasons, true); err != nil {
+		return err
+	}
+	return s.PutPlayers(ctx, f.Players, true)
+}
+
+// LoadFixture decodes a JSON Fixture from r and loads it into the MemoryStore.
+func (s *MemoryStore) LoadFixture(r io.Reader) error {
+	var f Fixture
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return err
+	}
+	return f.Load(context.Background(), s)
+}
+
+// LoadFixtureFile loads a JSON Fixture from the named file into the MemoryStore.
+func (s *MemoryStore) LoadFixtureFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := s.LoadFixture(file); err != nil {
+		return fmt.Errorf("loading fixture %s: %v", path, err)
+	}
+	return nil
+}
+
+// memoryBackend implements docBackend with a map of maps.
+type memoryBackend struct {
+	mutex sync.RWMutex
+	docs  map[string]map[string][]byte
+}
+
+func (b *memoryBackend) get(collection, id string) ([]byte, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	data, ok := b.docs[collection][id]
+	if !ok {
+		return nil, fmt.Errorf("%s/%s: %w", collection, id, ErrNotFound)
+	}
+	return data, nil
+}
+
+func (b *memoryBackend) each(collection string, f func(id string, data []byte) error) error {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	for id, data := range b.docs[collection] {
+		if err := f(id, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *memoryBackend) put(collection string, docs map[string][]byte, overwrite bool) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	c, ok := b.docs[collection]
+	if !ok {
+		c = make(map[string][]byte)
+		b.docs[collection] = c
+	}
+	if !overwrite {
+		for id := range docs {
+			if _, ok := c[id]; ok {
+				return fmt.Errorf("%s/%s: %w", collection, id, ErrExists)
+			}
+		}
+	}
+	for id, data := range docs {
+		c[id] = data
+	}
+	return nil
+}
+
+func (b *memoryBackend) update(collection string, ids []string, f func(id string, data []byte) ([]byte, error)) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	c := b.docs[collection]
+	updated := make(map[string][]byte, len(ids))
+	for _, id := range ids {
+		old, ok := c[id]
+		if !ok {
+			return fmt.Errorf("%s/%s: %w", collection, id, ErrNotFound)
+		}
+		data, err := f(id, old)
+		if err != nil {
+			return err
+		}
+		updated[id] = data
+	}
+	for id, data := range updated {
+		c[id] = data
+	}
+	return nil
+}
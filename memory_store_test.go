@@ -0,0 +1,125 @@
+package pickem
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/firestore"
+)
+
+func fixtureStore(t *testing.T) *MemoryStore {
+	t.Helper()
+	s := NewMemoryStore()
+	if err := s.LoadFixtureFile("testdata/fixtures.json"); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestMemoryStore_LoadFixture(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+
+	teams, err := s.Teams(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(teams) != 5 {
+		t.Errorf("expected 5 teams, got %d", len(teams))
+	}
+
+	venue, err := s.Venue(ctx, "30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !venue.Dome || len(venue.HomeTeams) != 1 || venue.HomeTeams[0].ID != "Gamma" {
+		t.Errorf("unexpected venue %v", venue)
+	}
+	if venue.HomeTeams[0].Parent.ID != TeamsCollection {
+		t.Errorf("expected reference to %s, got %s", TeamsCollection, venue.HomeTeams[0].Parent.ID)
+	}
+
+	games, err := s.Games(ctx, GameQuery{Season: 2018})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(games) != 1 || *games["001"].HomePoints != 35 {
+		t.Errorf("unexpected games %v", games)
+	}
+
+	seasons, err := s.Seasons(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seasons) != 2 || seasons["2019"].Year != 2019 {
+		t.Errorf("unexpected seasons %v", seasons)
+	}
+	if season, err := s.Season(ctx, games["001"].Season.ID); err != nil || season.Year != 2018 {
+		t.Errorf("expected season 2018, got %v (%v)", season, err)
+	}
+
+	player, err := s.Player(ctx, "Player A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if player.Name != "Player A" {
+		t.Errorf("expected Player A, got %s", player.Name)
+	}
+
+	if err := s.LoadFixture(strings.NewReader(`{"teams": [}`)); err == nil {
+		t.Errorf("expected error loading bad fixture")
+	}
+}
+
+func TestMemoryStore_PutTeams(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+
+	team := &Team{SchoolName: "Alpha", Abbreviation: "ALP"}
+	if err := s.PutTeams(ctx, map[string]*Team{"Alpha": team}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutTeams(ctx, map[string]*Team{"Alpha": team}, false); !errors.Is(err, ErrExists) {
+		t.Errorf("expected ErrExists, got %v", err)
+	}
+
+	// Stored documents are copies.
+	team.Abbreviation = "XXX"
+	got, err := s.Team(ctx, "Alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Abbreviation != "ALP" {
+		t.Errorf("expected ALP, got %s", got.Abbreviation)
+	}
+
+	if _, err := s.Team(ctx, "Beta"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMemoryStore_SetHomeVenues(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+
+	if err := s.SetHomeVenues(ctx, map[string]*firestore.DocumentRef{"Delta East": s.Ref(VenuesCollection, "99")}); err != nil {
+		t.Fatal(err)
+	}
+	team, err := s.Team(ctx, "Delta East")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if team.HomeVenue == nil || team.HomeVenue.ID != "99" || team.SchoolName != "Delta East" {
+		t.Errorf("expected only the home venue to change, got %v", team)
+	}
+
+	venues := map[string]*firestore.DocumentRef{"Alpha": s.Ref(VenuesCollection, "20"), "Omega": s.Ref(VenuesCollection, "99")}
+	if err := s.SetHomeVenues(ctx, venues); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if team, err := s.Team(ctx, "Alpha"); err != nil || team.HomeVenue.ID != "10" {
+		t.Errorf("expected failed update to leave Alpha unchanged, got %v (%v)", team, err)
+	}
+}
@@ -30,6 +30,9 @@ type GaussianSpreadModel struct {
 	homeBias  float64
 	closeBias float64
 	ratings   map[*Team]float64
+
+	// adjustment converts the conditions of a matchup into additional points of spread.
+	adjustment Adjustment
 }
 
 /*NewGaussianSpreadModel makes a model.
@@ -42,6 +45,8 @@ func NewGaussianSpreadModel(ratings map[*Team]float64, stdDev, homeBias, closeBi
 // Predict(NONE, NONE, loc): (NaN, NaN, error)
 // Predict(NONE, t2, loc): (0, 0, nil)
 // Predict(t1, NONE, loc): (1, 0, nil)
+//
+// The model's Adjustment for the Matchup's Conditions is added to the spread.
 func (m *GaussianSpreadModel) Predict(mu Matchup) (float64, float64, error) {
 	if mu.Team1 == nil && mu.Team2 == nil {
 		// Both teams have a bye week, so the winner is undefined.
@@ -55,7 +60,7 @@ func (m *GaussianSpreadModel) Predict(mu Matchup) (float64, float64, error) {
 		// The first team has a bye week, so wins automatically.
 		return 1., 0., nil
 	}
-	spread, err := m.spread(mu.Team1, mu.Team2, mu.Location)
+	spread, err := m.spread(mu.Team1, mu.Team2, mu.Location, mu.Conditions)
 	if err != nil {
 		return 0., 0., fmt.Errorf("Predict failed to calculate spread: %v", err)
 	}
@@ -64,7 +69,17 @@ func (m *GaussianSpreadModel) Predict(mu Matchup) (float64, float64, error) {
 	return prob, spread, nil
 }
 
-func (m GaussianSpreadModel) spread(t1, t2 *Team, loc RelativeLocation) (float64, error) {
+// spread returns the spread from ratings and location bias plus the adjustment for the conditions of the matchup.
+func (m GaussianSpreadModel) spread(t1, t2 *Team, loc RelativeLocation, c Conditions) (float64, error) {
+	diff, err := m.baseSpread(t1, t2, loc)
+	if err != nil {
+		return 0., err
+	}
+	return diff + m.adjustment.Points(c), nil
+}
+
+// baseSpread returns the spread from ratings and location bias alone, without any adjustment for the conditions of the matchup.
+func (m GaussianSpreadModel) baseSpread(t1, t2 *Team, loc RelativeLocation) (float64, error) {
 	r1, ok := m.ratings[t1]
 	if !ok {
 		return 0., fmt.Errorf("team 1 '%s' has no rating", t1.Name())
@@ -150,9 +165,20 @@ func (m *LookupModel) Predict(mu Matchup) (float64, float64, error) {
 		// The first team has a bye week, so wins automatically.
 		return 1., 0., nil
 	}
+	spread, err := m.spread(mu)
+	if err != nil {
+		return 0., 0., err
+	}
+
+	prob := m.dist.Cdf(spread)
+
+	return prob, spread, nil
+}
+
+func (m *LookupModel) spread(mu Matchup) (float64, error) {
 	spread, swap, ok := m.spreads.get(mu.Team1, mu.Team2)
 	if !ok {
-		return 0., 0., fmt.Errorf("spread between teams %s and %s not found", mu.Team1.Name(), mu.Team2.Name())
+		return 0., fmt.Errorf("spread between teams %s and %s not found", mu.Team1.Name(), mu.Team2.Name())
 	}
 	mult := 1.
 	if swap {
@@ -169,8 +195,5 @@ func (m *LookupModel) Predict(mu Matchup) (float64, float64, error) {
 	case Away:
 		spread -= m.homeBias * mult
 	}
-
-	prob := m.dist.Cdf(spread)
-
-	return prob, spread, nil
+	return spread, nil
 }
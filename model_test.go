@@ -121,7 +121,7 @@ func TestGaussianSpreadModel_Predict(t *testing.T) {
 				closeBias: tt.fields.closeBias,
 				ratings:   tt.fields.ratings,
 			}
-			got, got1, err := m.Predict(Matchup{tt.args.t1, tt.args.t2, tt.args.loc})
+			got, got1, err := m.Predict(Matchup{Team1: tt.args.t1, Team2: tt.args.t2, Location: tt.args.loc})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GaussianSpreadModel.Predict() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -237,7 +237,7 @@ func TestLookupModel_Predict(t *testing.T) {
 				closeBias: tt.fields.closeBias,
 				spreads:   tt.fields.spreads,
 			}
-			got, got1, err := m.Predict(Matchup{tt.args.t1, tt.args.t2, tt.args.loc})
+			got, got1, err := m.Predict(Matchup{Team1: tt.args.t1, Team2: tt.args.t2, Location: tt.args.loc})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("LookupModel.Predict() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -311,7 +311,7 @@ func TestNewGaussianSpreadModel(t *testing.T) {
 	rm[teamA] = 0.
 	rm[teamB] = 1.
 
-	want := &GaussianSpreadModel{prob.Normal{Mu: 0, Sigma: 12}, 2, 1, rm}
+	want := &GaussianSpreadModel{dist: prob.Normal{Mu: 0, Sigma: 12}, homeBias: 2, closeBias: 1, ratings: rm}
 
 	type args struct {
 		ratings   map[*Team]float64
@@ -0,0 +1,50 @@
+package pickem
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/atgjack/prob"
+)
+
+// coverProbability returns the probability that the first team beats a noisy line, given the predicted spread of the first team.
+// The final margin is normally distributed about the spread with the model's standard deviation, and the line is perturbed by
+// independent normal noise, so their difference is normally distributed with the combined variance.
+func coverProbability(spread, line, stdDev, noiseStdDev float64) float64 {
+	dist := prob.Normal{Mu: 0, Sigma: math.Hypot(stdDev, noiseStdDev)}
+	return dist.Cdf(spread - line)
+}
+
+// NoisySpreadPicks picks the side of each game in a slate most likely to cover a noisy version of the published line.
+//
+// The lines are given in the same sense as predicted spreads:  a line of 3 means the first team of the Matchup is favored
+// by 3 points and must win by more than 3 points to cover.  In a noisy-spread contest, the line each game is scored against
+// is the published line plus normally distributed noise with standard deviation noiseStdDev.  The predicted margin of each
+// game is normally distributed about the predicter's spread with the predicter's own standard deviation, as reported by
+// PredictMargin.
+//
+// The Prob of each Pick is the probability that the picked team covers, and the Spread of each Pick is the predicted spread
+// in favor of the picked team minus the line in favor of the picked team.
+func NoisySpreadPicks(slate []*Matchup, lines []float64, predicter MarginPredicter, noiseStdDev float64) (Picks, error) {
+	if len(lines) != len(slate) {
+		return nil, fmt.Errorf("mismatched length of slate (%d) and lines (%d)", len(slate), len(lines))
+	}
+	if noiseStdDev < 0 {
+		return nil, fmt.Errorf("noiseStdDev must be non-negative, got %f", noiseStdDev)
+	}
+
+	picks := make(Picks, len(slate))
+	for i, m := range slate {
+		if m == nil || m.Team1 == nil || m.Team2 == nil {
+			return nil, fmt.Errorf("matchup %d of slate is missing a team", i)
+		}
+		d, err := predicter.PredictMargin(*m)
+		if err != nil {
+			return nil, err
+		}
+		spread := d.Mean()
+		p := coverProbability(spread, lines[i], d.StdDev(), noiseStdDev)
+		picks[i] = straightUpPick(m, p, spread-lines[i], p >= .5)
+	}
+	return picks, nil
+}
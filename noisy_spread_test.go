@@ -0,0 +1,79 @@
+package pickem
+
+import (
+	"math"
+	"testing"
+
+	"github.com/atgjack/prob"
+)
+
+func TestNoisySpreadPicks(t *testing.T) {
+	teamA := fakeTeam("A")
+	teamB := fakeTeam("B")
+	teamC := fakeTeam("C")
+	rm := map[*Team]float64{teamA: 10, teamB: 3, teamC: 5}
+	model := NewGaussianSpreadModel(rm, 12, 0, 0)
+
+	slate := []*Matchup{
+		NewMatchup(teamA, teamB, Neutral), // A by 7
+		NewMatchup(teamB, teamC, Neutral), // C by 2
+		NewMatchup(teamA, teamC, Neutral), // A by 5
+	}
+	lines := []float64{10, -1, 5}
+
+	picks, err := NoisySpreadPicks(slate, lines, model, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	combined := prob.Normal{Mu: 0, Sigma: 13}
+	want := []struct {
+		team   *Team
+		prob   float64
+		spread float64
+	}{
+		{teamB, combined.Cdf(3), 3},
+		{teamC, combined.Cdf(1), 1},
+		{teamA, .5, 0},
+	}
+	for i, w := range want {
+		if picks[i].Team != w.team {
+			t.Errorf("pick %d: expected %s, got %s", i, w.team.Names[0], picks[i].Team.Names[0])
+		}
+		if math.Abs(picks[i].Prob-w.prob) > 1e-12 {
+			t.Errorf("pick %d: expected probability %v, got %v", i, w.prob, picks[i].Prob)
+		}
+		if math.Abs(picks[i].Spread-w.spread) > 1e-12 {
+			t.Errorf("pick %d: expected spread %v, got %v", i, w.spread, picks[i].Spread)
+		}
+	}
+
+	// Without noise, the cover probability is that of the model alone.
+	picks, err = NoisySpreadPicks(slate[:1], lines[:1], model, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := picks[0].Prob, (prob.Normal{Mu: 0, Sigma: 12}).Cdf(3); math.Abs(got-want) > 1e-12 {
+		t.Errorf("expected probability %v, got %v", want, got)
+	}
+
+	if _, err := NoisySpreadPicks(slate, lines[:2], model, 5); err == nil {
+		t.Errorf("expected error for mismatched lines")
+	}
+	if _, err := NoisySpreadPicks(slate, lines, model, -1); err == nil {
+		t.Errorf("expected error for negative noise standard deviation")
+	}
+	if _, err := NoisySpreadPicks([]*Matchup{NewMatchup(teamA, nil, Neutral)}, lines[:1], model, 5); err == nil {
+		t.Errorf("expected error for bye")
+	}
+
+	// The model's own standard deviation is used.
+	wide := NewGaussianSpreadModel(rm, 24, 0, 0)
+	picks, err = NoisySpreadPicks(slate[:1], lines[:1], wide, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := picks[0].Prob, (prob.Normal{Mu: 0, Sigma: 24}).Cdf(3); math.Abs(got-want) > 1e-12 {
+		t.Errorf("expected probability %v with the wider model, got %v", want, got)
+	}
+}
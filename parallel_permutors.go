@@ -0,0 +1,206 @@
+package pickem
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// A ScoredPermutation is a permutation and the score given to it.
+type ScoredPermutation struct {
+	Permutation []int
+	Score       float64
+}
+
+// better returns true if a ranks ahead of b:  higher scores first, with ties broken by lexicographic order of the permutations.
+// NaN scores rank after every other score, so that the order is total and does not depend on how the work was sharded.
+func (a ScoredPermutation) better(b ScoredPermutation) bool {
+	aNaN, bNaN := math.IsNaN(a.Score), math.IsNaN(b.Score)
+	if aNaN != bNaN {
+		return bNaN
+	}
+	if !aNaN && a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	for i := range a.Permutation {
+		if a.Permutation[i] != b.Permutation[i] {
+			return a.Permutation[i] < b.Permutation[i]
+		}
+	}
+	return false
+}
+
+// scoredHeap is a min-heap of ScoredPermutations with the worst-ranked permutation on top.
+type scoredHeap []ScoredPermutation
+
+func (h scoredHeap) Len() int            { return len(h) }
+func (h scoredHeap) Less(i, j int) bool  { return h[j].better(h[i]) }
+func (h scoredHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *scoredHeap) Push(x interface{}) { *h = append(*h, x.(ScoredPermutation)) }
+func (h *scoredHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// offer keeps the permutation if it ranks among the best k seen so far.  The permutation is copied only if it is kept.
+func (h *scoredHeap) offer(k int, perm []int, score float64) {
+	sp := ScoredPermutation{Permutation: perm, Score: score}
+	if len(*h) < k {
+		sp.Permutation = clone(perm)
+		heap.Push(h, sp)
+		return
+	}
+	if sp.better((*h)[0]) {
+		sp.Permutation = clone(perm)
+		(*h)[0] = sp
+		heap.Fix(h, 0)
+	}
+}
+
+// shardsPerWorker is the minimum number of shards made for each worker, so that uneven shards balance out.
+const shardsPerWorker = 4
+
+// prefixes returns every distinct prefix of the given length that can be made from the multiset of sorted elements, in lexicographic order.
+func prefixes(elements []int, length int) [][]int {
+	out := make([][]int, 0)
+	used := make([]bool, len(elements))
+	prefix := make([]int, 0, length)
+	var recurse func()
+	recurse = func() {
+		if len(prefix) == length {
+			out = append(out, clone(prefix))
+			return
+		}
+		for i, e := range elements {
+			// Only the first unused copy of a repeated element may start a branch.
+			if used[i] || (i > 0 && elements[i-1] == e && !used[i-1]) {
+				continue
+			}
+			used[i] = true
+			prefix = append(prefix, e)
+			recurse()
+			prefix = prefix[:len(prefix)-1]
+			used[i] = false
+		}
+	}
+	recurse()
+	return out
+}
+
+// shard splits the permutations of the sorted elements into groups sharing a fixed prefix.
+// The prefix is lengthened until there are enough shards to keep the workers busy.
+func shard(elements []int, workers int) [][]int {
+	shards := [][]int{{}}
+	for length := 1; length <= len(elements) && len(shards) < workers*shardsPerWorker; length++ {
+		shards = prefixes(elements, length)
+	}
+	return shards
+}
+
+// remove returns the sorted elements left after removing those in prefix.
+func remove(elements []int, prefix []int) []int {
+	counts := make(map[int]int)
+	for _, p := range prefix {
+		counts[p]++
+	}
+	out := make([]int, 0, len(elements)-len(prefix))
+	for _, e := range elements {
+		if counts[e] > 0 {
+			counts[e]--
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// checkInterval is the number of permutations scored between checks for cancellation.
+const checkInterval = 1 << 10
+
+// bestK scores every distinct permutation of the sorted elements in parallel and returns the k best.
+func bestK(ctx context.Context, elements []int, k, workers int, score func([]int) float64) ([]ScoredPermutation, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("number of permutations to return must be positive, got %d", k)
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	shards := make(chan []int)
+	go func() {
+		defer close(shards)
+		for _, prefix := range shard(elements, workers) {
+			select {
+			case shards <- prefix:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make([]scoredHeap, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(h *scoredHeap) {
+			defer wg.Done()
+			perm := make([]int, len(elements))
+			n := 0
+			for prefix := range shards {
+				copy(perm, prefix)
+				suffix := perm[len(prefix):]
+				copy(suffix, remove(elements, prefix))
+				for ok := true; ok; ok = nextPermutation(suffix) {
+					n++
+					if n%checkInterval == 0 && ctx.Err() != nil {
+						return
+					}
+					h.offer(k, perm, score(perm))
+				}
+			}
+		}(&results[w])
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	best := make([]ScoredPermutation, 0, k*workers)
+	for _, h := range results {
+		best = append(best, h...)
+	}
+	sort.Slice(best, func(i, j int) bool { return best[i].better(best[j]) })
+	if len(best) > k {
+		best = best[:k]
+	}
+	return best, nil
+}
+
+// BestK scores every permutation with the given function and returns the k best-scoring permutations in order of decreasing score.
+// Ties are broken by lexicographic order of the permutations, so the result does not depend on the number of workers.
+//
+// The permutations are split into shards by fixing their leading elements, and the shards are scored by the given number of
+// concurrent workers (or runtime.GOMAXPROCS(0) workers if workers <= 0).  The score function must be safe for concurrent use
+// and must neither modify nor retain the permutation passed to it.  If the context is cancelled, the context's error is returned.
+func (ip *IndexPermutor) BestK(ctx context.Context, k, workers int, score func([]int) float64) ([]ScoredPermutation, error) {
+	return bestK(ctx, clone(ip.indices), k, workers, score)
+}
+
+// BestK scores every distinct permutation with the given function and returns the k best-scoring permutations in order of decreasing score.
+// Ties are broken by lexicographic order of the permutations, so the result does not depend on the number of workers.
+//
+// The permutations are split into shards by fixing their leading elements, and the shards are scored by the given number of
+// concurrent workers (or runtime.GOMAXPROCS(0) workers if workers <= 0).  The score function must be safe for concurrent use
+// and must neither modify nor retain the permutation passed to it.  If the context is cancelled, the context's error is returned.
+func (ip *IdenticalPermutor) BestK(ctx context.Context, k, workers int, score func([]int) float64) ([]ScoredPermutation, error) {
+	return bestK(ctx, clone(ip.indices), k, workers, score)
+}
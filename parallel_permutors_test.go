@@ -0,0 +1,162 @@
+package pickem
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// sumScore weights each position differently so permutations have distinct scores, with some ties.
+func sumScore(p []int) float64 {
+	s := 0.
+	for i, x := range p {
+		s += float64((i%3)*x) + float64(x*x)/float64(i+1)
+	}
+	return s
+}
+
+func bruteForceBestK(itr <-chan []int, k int) []ScoredPermutation {
+	all := make([]ScoredPermutation, 0)
+	for p := range itr {
+		all = append(all, ScoredPermutation{Permutation: p, Score: sumScore(p)})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].better(all[j]) })
+	if len(all) > k {
+		all = all[:k]
+	}
+	return all
+}
+
+func TestNextPermutation(t *testing.T) {
+	x := []int{0, 0, 1, 2, 2}
+	n := 1
+	for nextPermutation(x) {
+		n++
+	}
+	if n != 30 {
+		t.Errorf("expected 5!/2!/2! = 30, got %d", n)
+	}
+	if !check(x, []int{2, 2, 1, 0, 0}) {
+		t.Errorf("expected last permutation, got %v", x)
+	}
+}
+
+func TestIndexPermutor_BestK(t *testing.T) {
+	p := NewIndexPermutor(7)
+	want := bruteForceBestK(p.Iterator(), 10)
+
+	for _, workers := range []int{1, 2, 3, 8, 100, 0} {
+		got, err := p.BestK(context.Background(), 10, workers, sumScore)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%d workers: expected %v, got %v", workers, want, got)
+		}
+	}
+
+	// More than there are
+	got, err := NewIndexPermutor(3).BestK(context.Background(), 10, 2, sumScore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 6 {
+		t.Errorf("expected 6 permutations, got %d", len(got))
+	}
+
+	if _, err := p.BestK(context.Background(), 0, 1, sumScore); err == nil {
+		t.Errorf("expected error for k = 0")
+	}
+}
+
+func TestIdenticalPermutor_BestK(t *testing.T) {
+	p := NewIdenticalPermutor(3, 0, 2, 3)
+	want := bruteForceBestK(p.Iterator(), 25)
+
+	for _, workers := range []int{1, 2, 5, 64} {
+		got, err := p.BestK(context.Background(), 25, workers, sumScore)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%d workers: expected %v, got %v", workers, want, got)
+		}
+	}
+
+	// All of them
+	got, err := p.BestK(context.Background(), 1000, 4, sumScore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nop := p.NumberOfPermutations(); int64(len(got)) != nop.Int64() {
+		t.Errorf("expected %v permutations, got %d", nop, len(got))
+	}
+}
+
+func TestIndexPermutor_BestK_NaN(t *testing.T) {
+	// Every permutation starting with 0 or 1 has a NaN score, and those are the best by sumScore.
+	score := func(p []int) float64 {
+		if p[0] < 2 {
+			return math.NaN()
+		}
+		return -sumScore(p)
+	}
+	p := NewIndexPermutor(5)
+	permutations := func(sps []ScoredPermutation) [][]int {
+		out := make([][]int, len(sps))
+		for i, sp := range sps {
+			out[i] = sp.Permutation
+		}
+		return out
+	}
+
+	var want [][]int
+	for _, workers := range []int{1, 2, 3, 8} {
+		got, err := p.BestK(context.Background(), 100, workers, score)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 100 {
+			t.Fatalf("%d workers: expected 100 permutations, got %d", workers, len(got))
+		}
+		for i, sp := range got {
+			if math.IsNaN(sp.Score) != (i >= 72) {
+				t.Errorf("%d workers: expected NaN scores last, got %v at %d", workers, sp.Score, i)
+				break
+			}
+		}
+		if want == nil {
+			want = permutations(got)
+		} else if !reflect.DeepEqual(permutations(got), want) {
+			t.Errorf("%d workers: expected %v, got %v", workers, want, permutations(got))
+		}
+	}
+}
+
+func TestBestK_Cancel(t *testing.T) {
+	p := NewIndexPermutor(12)
+	ctx, cancel := context.WithCancel(context.Background())
+	n := 0
+	score := func(x []int) float64 {
+		// Only called from one worker
+		n++
+		if n == 10000 {
+			cancel()
+		}
+		return 0
+	}
+	if _, err := p.BestK(ctx, 1, 1, score); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func BenchmarkBestK10(b *testing.B) {
+	p := NewIndexPermutor(10)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		p.BestK(context.Background(), 10, 0, sumScore)
+	}
+}
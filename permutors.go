@@ -1,9 +1,9 @@
 package pickem
 
 import (
+	"context"
+	"fmt"
 	"math/big"
-
-	"github.com/segmentio/fasthash/jody"
 )
 
 // IndexPermutor permutes an integer range from 0 to N.
@@ -72,38 +72,95 @@ func (ip IdenticalPermutor) NumberOfPermutations() *big.Int {
 	return fact
 }
 
-func hash(v []int) uint64 {
-	h := jody.HashUint64(uint64(v[0]))
-	for _, x := range v[1:] {
-		h = jody.AddUint64(h, uint64(x))
-	}
-	return h
-}
-
 func clone(x []int) []int {
 	out := make([]int, len(x))
 	copy(out, x)
 	return out
 }
 
-// Iterator returns a channel-backed iterator that produces iterations of the identical sets represented by an IdenticalPermutor.
-// The channel closes once all the permutations have been pushed.
-// The implementation uses Heap's algorithm (non-recursive) and a map of hashes to keep track of which permutations have been seen already.
-// This means for very large permutations, there is some small probability that a hash collision will occur and certain permutations could be skipped in the iteration.
-func (ip *IdenticalPermutor) Iterator() <-chan []int {
-	ch := make(chan []int)
+// nextPermutation rearranges x into the lexicographically next permutation of its elements, returning false if x is already the last permutation.
+// Repeated elements are handled naturally, so starting from sorted order every distinct permutation is visited exactly once.
+func nextPermutation(x []int) bool {
+	i := len(x) - 2
+	for i >= 0 && x[i] >= x[i+1] {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	j := len(x) - 1
+	for x[j] <= x[i] {
+		j--
+	}
+	x[i], x[j] = x[j], x[i]
+	for l, r := i+1, len(x)-1; l < r; l, r = l+1, r-1 {
+		x[l], x[r] = x[r], x[l]
+	}
+	return true
+}
 
-	go func() {
-		visited := make(map[uint64]bool)
-		out := make([]int, ip.Len())
-		counter := make([]int, len(out))
+// PermutationIterator steps through permutations on demand, without a goroutine or channel.  Use it like so:
+//
+//	itr := p.Permutations()
+//	for itr.Next() {
+//	    perm := itr.Permutation()
+//	    ...
+//	}
+//	if err := itr.Err(); err != nil {
+//	    ...
+//	}
+//
+// An iterator can be abandoned at any point without leaking resources.
+type PermutationIterator struct {
+	out     []int
+	step    func([]int) bool
+	started bool
+	done    bool
+	ctx     context.Context
+	err     error
+}
 
-		copy(out, ip.indices)
+// Next advances the iterator to the next permutation, returning false when the permutations are exhausted or the context is cancelled.
+func (itr *PermutationIterator) Next() bool {
+	if itr.done {
+		return false
+	}
+	if itr.ctx != nil {
+		select {
+		case <-itr.ctx.Done():
+			itr.err = itr.ctx.Err()
+			itr.done = true
+			return false
+		default:
+		}
+	}
+	if !itr.started {
+		itr.started = true
+		return true
+	}
+	if !itr.step(itr.out) {
+		itr.done = true
+		return false
+	}
+	return true
+}
+
+// Permutation returns the current permutation.  The returned slice is reused by the iterator, so it is only valid until the next call to Next
+// and must not be modified.  Clone it to keep it.
+func (itr *PermutationIterator) Permutation() []int {
+	return itr.out
+}
 
-		visited[hash(out)] = true
-		ch <- clone(out)
+// Err returns the context's error if the iteration was stopped by cancellation, else nil.
+func (itr *PermutationIterator) Err() error {
+	return itr.err
+}
 
-		i := 0
+// heapStep returns a function that advances a permutation by one step of Heap's algorithm (non-recursive).
+func heapStep(n int) func([]int) bool {
+	counter := make([]int, n)
+	i := 0
+	return func(out []int) bool {
 		for i < len(out) {
 			if counter[i] < i {
 				if i%2 == 0 {
@@ -111,19 +168,48 @@ func (ip *IdenticalPermutor) Iterator() <-chan []int {
 				} else {
 					out[counter[i]], out[i] = out[i], out[counter[i]]
 				}
-
-				if _, ok := visited[hash(out)]; !ok {
-					visited[hash(out)] = true
-					ch <- clone(out)
-				}
-
 				counter[i]++
 				i = 0
-
-			} else {
-				counter[i] = 0
-				i++
+				return true
 			}
+			counter[i] = 0
+			i++
+		}
+		return false
+	}
+}
+
+// Permutations returns a PermutationIterator over the same permutations, in the same order, as Iterator.
+func (ip *IndexPermutor) Permutations() *PermutationIterator {
+	return &PermutationIterator{out: clone(ip.indices), step: heapStep(ip.Len())}
+}
+
+// PermutationsContext is like Permutations, but the iterator stops early if the context is cancelled.
+func (ip *IndexPermutor) PermutationsContext(ctx context.Context) *PermutationIterator {
+	itr := ip.Permutations()
+	itr.ctx = ctx
+	return itr
+}
+
+// Permutations returns a PermutationIterator over the same permutations, in the same order, as Iterator.
+func (ip *IdenticalPermutor) Permutations() *PermutationIterator {
+	return &PermutationIterator{out: clone(ip.indices), step: nextPermutation}
+}
+
+// PermutationsContext is like Permutations, but the iterator stops early if the context is cancelled.
+func (ip *IdenticalPermutor) PermutationsContext(ctx context.Context) *PermutationIterator {
+	itr := ip.Permutations()
+	itr.ctx = ctx
+	return itr
+}
+
+// iterate pushes copies of the permutations from a PermutationIterator to a channel, closing the channel when done.
+func iterate(itr *PermutationIterator) <-chan []int {
+	ch := make(chan []int)
+
+	go func() {
+		for itr.Next() {
+			ch <- clone(itr.Permutation())
 		}
 		close(ch)
 	}()
@@ -131,41 +217,178 @@ func (ip *IdenticalPermutor) Iterator() <-chan []int {
 	return ch
 }
 
+// Iterator returns a channel-backed iterator that produces iterations of the identical sets represented by an IdenticalPermutor.
+// The channel closes once all the permutations have been pushed.
+// The implementation steps through the permutations in lexicographic order, so each distinct permutation is produced exactly once
+// and only the current permutation is kept in memory.
+// The channel must be drained, or the goroutine feeding it will never exit:  use Permutations to stop early.
+func (ip *IdenticalPermutor) Iterator() <-chan []int {
+	return iterate(ip.Permutations())
+}
+
 // Iterator returns a channel-backed iterator that produces iterations of the identical sets represented by an IdenticalPermutor.
 // The channel closes once all the permutations have been pushed.
 // The implementation uses Heap's algorithm (non-recursive).
+// The channel must be drained, or the goroutine feeding it will never exit:  use Permutations to stop early.
 func (ip *IndexPermutor) Iterator() <-chan []int {
-	ch := make(chan []int)
-
-	go func() {
-		out := make([]int, ip.Len())
-		counter := make([]int, len(out))
-
-		copy(out, ip.indices)
+	return iterate(ip.Permutations())
+}
 
-		ch <- clone(out)
+// multinomial returns the number of distinct permutations of a multiset with counts[i] copies of integer i.
+func multinomial(counts []int) *big.Int {
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	m := factorial(n)
+	for _, c := range counts {
+		m.Div(m, factorial(c))
+	}
+	return m
+}
 
-		i := 0
-		for i < len(out) {
-			if counter[i] < i {
-				if i%2 == 0 {
-					out[0], out[i] = out[i], out[0]
-				} else {
-					out[counter[i]], out[i] = out[i], out[counter[i]]
-				}
+// rankMultiset returns the lexicographic rank of perm among the distinct permutations of a multiset with counts[i] copies of integer i.
+func rankMultiset(counts []int, perm []int) (*big.Int, error) {
+	counts = clone(counts)
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	if len(perm) != n {
+		return nil, fmt.Errorf("permutation of length %d must be of length %d", len(perm), n)
+	}
 
-				ch <- clone(out)
+	rank := new(big.Int)
+	m := multinomial(counts)
+	sub := new(big.Int)
+	for _, x := range perm {
+		if x < 0 || x >= len(counts) || counts[x] == 0 {
+			return nil, fmt.Errorf("permutation %v is not a permutation of the set", perm)
+		}
+		// Count the permutations that start with each smaller value.
+		for v := 0; v < x; v++ {
+			if counts[v] == 0 {
+				continue
+			}
+			sub.Mul(m, big.NewInt(int64(counts[v])))
+			sub.Div(sub, big.NewInt(int64(n)))
+			rank.Add(rank, sub)
+		}
+		m.Mul(m, big.NewInt(int64(counts[x])))
+		m.Div(m, big.NewInt(int64(n)))
+		counts[x]--
+		n--
+	}
+	return rank, nil
+}
 
-				counter[i]++
-				i = 0
+// unrankMultiset returns the permutation with the given lexicographic rank among the distinct permutations of a multiset with counts[i] copies of integer i.
+func unrankMultiset(counts []int, rank *big.Int) ([]int, error) {
+	counts = clone(counts)
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	m := multinomial(counts)
+	if rank.Sign() < 0 || rank.Cmp(m) >= 0 {
+		return nil, fmt.Errorf("rank %v must be in the range [0, %v)", rank, m)
+	}
 
-			} else {
-				counter[i] = 0
-				i++
+	r := new(big.Int).Set(rank)
+	sub := new(big.Int)
+	perm := make([]int, n)
+	for i := range perm {
+		for v, c := range counts {
+			if c == 0 {
+				continue
+			}
+			sub.Mul(m, big.NewInt(int64(c)))
+			sub.Div(sub, big.NewInt(int64(n)))
+			if r.Cmp(sub) < 0 {
+				perm[i] = v
+				m.Set(sub)
+				counts[v]--
+				n--
+				break
 			}
+			r.Sub(r, sub)
 		}
-		close(ch)
-	}()
+	}
+	return perm, nil
+}
 
-	return ch
+func (ip IndexPermutor) counts() []int {
+	counts := make([]int, ip.Len())
+	for i := range counts {
+		counts[i] = 1
+	}
+	return counts
+}
+
+// Rank returns the position of the given permutation in the lexicographic ordering of all permutations, counting from zero.
+// Note that this is not the order in which Iterator produces permutations:  use PermutationsFrom to resume from a rank.
+// An error is returned if the argument is not a permutation of the set.
+func (ip IndexPermutor) Rank(perm []int) (*big.Int, error) {
+	return rankMultiset(ip.counts(), perm)
+}
+
+// Permutation returns the permutation at the given position in the lexicographic ordering of all permutations, counting from zero.
+// An error is returned if the rank is not in the range [0, NumberOfPermutations()).
+func (ip IndexPermutor) Permutation(rank *big.Int) ([]int, error) {
+	return unrankMultiset(ip.counts(), rank)
+}
+
+// PermutationsFrom returns a PermutationIterator over the permutations in lexicographic order, starting with the permutation
+// at the given rank, so that the nth permutation produced has rank+n.  Unlike Permutations, it can resume an iteration
+// checkpointed with Rank.  An error is returned if the rank is not in the range [0, NumberOfPermutations()).
+func (ip *IndexPermutor) PermutationsFrom(rank *big.Int) (*PermutationIterator, error) {
+	perm, err := ip.Permutation(rank)
+	if err != nil {
+		return nil, err
+	}
+	return &PermutationIterator{out: perm, step: nextPermutation}, nil
+}
+
+// PermutationsFromContext is like PermutationsFrom, but the iterator stops early if the context is cancelled.
+func (ip *IndexPermutor) PermutationsFromContext(ctx context.Context, rank *big.Int) (*PermutationIterator, error) {
+	itr, err := ip.PermutationsFrom(rank)
+	if err != nil {
+		return nil, err
+	}
+	itr.ctx = ctx
+	return itr, nil
+}
+
+// Rank returns the position of the given permutation in the lexicographic ordering of all distinct permutations, counting from zero.
+// This is the order in which Iterator produces permutations.
+// An error is returned if the argument is not a permutation of the set.
+func (ip IdenticalPermutor) Rank(perm []int) (*big.Int, error) {
+	return rankMultiset(ip.sets, perm)
+}
+
+// Permutation returns the permutation at the given position in the lexicographic ordering of all distinct permutations, counting from zero.
+// An error is returned if the rank is not in the range [0, NumberOfPermutations()).
+func (ip IdenticalPermutor) Permutation(rank *big.Int) ([]int, error) {
+	return unrankMultiset(ip.sets, rank)
+}
+
+// PermutationsFrom returns a PermutationIterator over the distinct permutations in lexicographic order, starting with the
+// permutation at the given rank, so that the nth permutation produced has rank+n.
+// An error is returned if the rank is not in the range [0, NumberOfPermutations()).
+func (ip *IdenticalPermutor) PermutationsFrom(rank *big.Int) (*PermutationIterator, error) {
+	perm, err := ip.Permutation(rank)
+	if err != nil {
+		return nil, err
+	}
+	return &PermutationIterator{out: perm, step: nextPermutation}, nil
+}
+
+// PermutationsFromContext is like PermutationsFrom, but the iterator stops early if the context is cancelled.
+func (ip *IdenticalPermutor) PermutationsFromContext(ctx context.Context, rank *big.Int) (*PermutationIterator, error) {
+	itr, err := ip.PermutationsFrom(rank)
+	if err != nil {
+		return nil, err
+	}
+	itr.ctx = ctx
+	return itr, nil
 }
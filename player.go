@@ -1,15 +1,204 @@
 package pickem
 
 import (
+	"fmt"
+	"sort"
 	"time"
 
 	"cloud.google.com/go/firestore"
 )
 
+// Contest names a Pick 'Em contest.
+type Contest string
+
+const (
+	// StraightUpContest is picking the winner of every game.
+	StraightUpContest Contest = "straight_up"
+
+	// NoisySpreadContest is picking the side of every game that covers a noisy spread.
+	NoisySpreadContest Contest = "noisy_spread"
+
+	// SuperdogContest is picking underdogs worth points if they win.
+	SuperdogContest Contest = "superdog"
+
+	// PonyContest is picking the winner of every game with confidence points.
+	PonyContest Contest = "pony"
+
+	// BeatTheStreakContest is picking each team once over the season to win.
+	BeatTheStreakContest Contest = "beat_the_streak"
+)
+
+// A PickRecord is a pick made by a player in a contest.
+type PickRecord struct {
+	Contest Contest                `json:"contest" firestore:"contest"`
+	Season  *firestore.DocumentRef `json:"season" firestore:"season"`
+	Week    int                    `json:"week" firestore:"week"`
+	// Team is the picked team, or nil if a bye was used.
+	Team *firestore.DocumentRef `json:"team" firestore:"team"`
+	// Confidence is the number of points assigned to the pick in contests that use them.
+	Confidence int `json:"confidence" firestore:"confidence"`
+}
+
 // Player represents a player's current status in the competition.
 type Player struct {
 	Name     string    `json:"name" firestore:"name"`
 	JoinDate time.Time `json:"join_date" firestore:"join_date"`
+
+	// History is the player's pick history across all contests.
+	History []PickRecord `json:"history" firestore:"history"`
+
+	// RemainingTeams are the teams the player has yet to pick in the beat the streak contest.
+	RemainingTeams []*firestore.DocumentRef `json:"remaining_teams" firestore:"remaining_teams"`
+	// RemainingByes is the number of weeks the player can skip in the beat the streak contest.
+	RemainingByes int `json:"remaining_byes" firestore:"remaining_byes"`
+	// RemainingDoubleDowns is the number of weeks the player can pick two teams in the beat the streak contest.
+	RemainingDoubleDowns int `json:"remaining_double_downs" firestore:"remaining_double_downs"`
+}
+
+// ContestPicks returns the player's picks in the given contest, in the order they were made.
+func (p *Player) ContestPicks(contest Contest) []PickRecord {
+	picks := make([]PickRecord, 0)
+	for _, pick := range p.History {
+		if pick.Contest == contest {
+			picks = append(picks, pick)
+		}
+	}
+	return picks
+}
+
+// Remaining looks up the teams the player has yet to pick in the beat the streak contest.  The teams map looks up Teams by
+// document ID, as returned by Store.Teams.  Pass the same map used to build a SeasonSchedule and fit a model, so that the
+// returned Teams are the keys of its schedules and can be passed to BestStreak.
+func (p *Player) Remaining(teams map[string]*Team) ([]*Team, error) {
+	remaining := make([]*Team, len(p.RemainingTeams))
+	for i, ref := range p.RemainingTeams {
+		team, ok := teams[ref.ID]
+		if !ok {
+			return nil, fmt.Errorf("team '%s' not found", ref.ID)
+		}
+		remaining[i] = team
+	}
+	return remaining, nil
+}
+
+// WeekTypes returns a permutor over the player's remaining weeks in the beat the streak contest, where each week is
+// a bye (0 picks), a single pick (1 pick), or a double-down (2 picks).  This can be passed to BestStreak.
+func (p *Player) WeekTypes() (*IdenticalPermutor, error) {
+	if p.RemainingByes < 0 {
+		return nil, fmt.Errorf("player '%s' has a negative number of byes remaining (%d)", p.Name, p.RemainingByes)
+	}
+	if p.RemainingDoubleDowns < 0 {
+		return nil, fmt.Errorf("player '%s' has a negative number of double-downs remaining (%d)", p.Name, p.RemainingDoubleDowns)
+	}
+	singles := len(p.RemainingTeams) - 2*p.RemainingDoubleDowns
+	if singles < 0 {
+		return nil, fmt.Errorf("player '%s' has %d teams remaining, which is not enough for %d double-downs", p.Name, len(p.RemainingTeams), p.RemainingDoubleDowns)
+	}
+	return NewIdenticalPermutor(p.RemainingByes, singles, p.RemainingDoubleDowns), nil
+}
+
+// RecordStreakPick records the player's beat the streak picks for a week.  No teams means a bye is used, and two teams means a
+// double-down is used.  The picked teams are removed from the player's remaining teams.
+func (p *Player) RecordStreakPick(season *firestore.DocumentRef, week int, teams ...*firestore.DocumentRef) error {
+	switch len(teams) {
+	case 0:
+		if p.RemainingByes <= 0 {
+			return fmt.Errorf("player '%s' has no byes remaining", p.Name)
+		}
+	case 1:
+	case 2:
+		if p.RemainingDoubleDowns <= 0 {
+			return fmt.Errorf("player '%s' has no double-downs remaining", p.Name)
+		}
+		if teams[0].ID == teams[1].ID {
+			return fmt.Errorf("player '%s' cannot pick team '%s' twice", p.Name, teams[0].ID)
+		}
+	default:
+		return fmt.Errorf("player '%s' cannot pick %d teams in one week", p.Name, len(teams))
+	}
+
+	remaining := make([]*firestore.DocumentRef, 0, len(p.RemainingTeams))
+	picked := make(map[string]bool)
+	for _, team := range teams {
+		picked[team.ID] = true
+	}
+	for _, ref := range p.RemainingTeams {
+		if picked[ref.ID] {
+			delete(picked, ref.ID)
+			continue
+		}
+		remaining = append(remaining, ref)
+	}
+	for id := range picked {
+		return fmt.Errorf("player '%s' has already picked team '%s'", p.Name, id)
+	}
+
+	p.RemainingTeams = remaining
+	switch len(teams) {
+	case 0:
+		p.RemainingByes--
+		p.History = append(p.History, PickRecord{Contest: BeatTheStreakContest, Season: season, Week: week})
+	case 2:
+		p.RemainingDoubleDowns--
+	}
+	for _, team := range teams {
+		p.History = append(p.History, PickRecord{Contest: BeatTheStreakContest, Season: season, Week: week, Team: team})
+	}
+	return nil
+}
+
+// PlayerMap is a collection of players keyed by ID, as returned by Store.Players.
+type PlayerMap map[string]*Player
+
+// streakKey identifies a player's beat the streak state independently of the order of the player's remaining teams.
+func (p *Player) streakKey() (string, error) {
+	wt, err := p.WeekTypes()
+	if err != nil {
+		return "", err
+	}
+	teams := make([]string, len(p.RemainingTeams))
+	for i, ref := range p.RemainingTeams {
+		teams[i] = ref.ID
+	}
+	sort.Strings(teams)
+	return fmt.Sprintf("%q %v", teams, wt.sets), nil
+}
+
+// StreakGroups groups players with equivalent beat the streak states:  the same remaining teams (in any order) and the same
+// remaining week types.  Players in the same group have the same optimal streak, so it only needs to be computed once per group.
+// Each group is a sorted list of player IDs, and groups are sorted by their first ID.
+func (pm PlayerMap) StreakGroups() ([][]string, error) {
+	byKey := make(map[string][]string)
+	for id, p := range pm {
+		key, err := p.streakKey()
+		if err != nil {
+			return nil, err
+		}
+		byKey[key] = append(byKey[key], id)
+	}
+
+	groups := make([][]string, 0, len(byKey))
+	for _, ids := range byKey {
+		sort.Strings(ids)
+		groups = append(groups, ids)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0] < groups[j][0] })
+	return groups, nil
+}
+
+// Duplicates returns the StreakGroups with more than one player, i.e. the players who are effectively tied in the beat the streak contest.
+func (pm PlayerMap) Duplicates() ([][]string, error) {
+	groups, err := pm.StreakGroups()
+	if err != nil {
+		return nil, err
+	}
+	dups := make([][]string, 0)
+	for _, g := range groups {
+		if len(g) > 1 {
+			dups = append(dups, g)
+		}
+	}
+	return dups, nil
 }
 
 // PlayerPreferences holds preferred options for the player.
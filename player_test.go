@@ -1,12 +1,176 @@
 package pickem
 
-// func TestDuplicates(t *testing.T) {
-// 	pm := make(PlayerMap)
-// 	pm["A"] = &Player{name: "A", remaining: Remaining{"AAA", "BBB", "CCC"}, weekTypes: NewIdenticalPermutor(0, 3, 0)}
-// 	pm["B"] = &Player{name: "Dup A Identical", remaining: Remaining{"AAA", "BBB", "CCC"}, weekTypes: NewIdenticalPermutor(0, 3, 0)}
-// 	pm["C"] = &Player{name: "Dup A New Order", remaining: Remaining{"AAA", "CCC", "BBB"}, weekTypes: NewIdenticalPermutor(0, 3, 0)}
-// 	pm["D"] = &Player{name: "Not A New Teams", remaining: Remaining{"AAA", "BBB", "CCC", "DDD"}, weekTypes: NewIdenticalPermutor(0, 3, 0)}
-// 	pm["E"] = &Player{name: "Not A New Weeks", remaining: Remaining{"AAA", "BBB", "CCC"}, weekTypes: NewIdenticalPermutor(0, 1, 1)}
-
-// 	pm.Duplicates()
-// }
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/firestore"
+)
+
+func TestDuplicates(t *testing.T) {
+	s := NewMemoryStore()
+	teams := func(ids ...string) []*firestore.DocumentRef {
+		refs := make([]*firestore.DocumentRef, len(ids))
+		for i, id := range ids {
+			refs[i] = s.Ref(TeamsCollection, id)
+		}
+		return refs
+	}
+	pm := make(PlayerMap)
+	pm["A"] = &Player{Name: "A", RemainingTeams: teams("AAA", "BBB", "CCC")}
+	pm["B"] = &Player{Name: "Dup A Identical", RemainingTeams: teams("AAA", "BBB", "CCC")}
+	pm["C"] = &Player{Name: "Dup A New Order", RemainingTeams: teams("AAA", "CCC", "BBB")}
+	pm["D"] = &Player{Name: "Not A New Teams", RemainingTeams: teams("AAA", "BBB", "CCC", "DDD")}
+	pm["E"] = &Player{Name: "Not A New Weeks", RemainingTeams: teams("AAA", "BBB", "CCC"), RemainingDoubleDowns: 1}
+	pm["F"] = &Player{Name: "Dup E", RemainingTeams: teams("CCC", "BBB", "AAA"), RemainingDoubleDowns: 1}
+	pm["G"] = &Player{Name: "Not A New Byes", RemainingTeams: teams("AAA", "BBB", "CCC"), RemainingByes: 1}
+
+	groups, err := pm.StreakGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"A", "B", "C"}, {"D"}, {"E", "F"}, {"G"}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("expected %v, got %v", want, groups)
+	}
+
+	dups, err := pm.Duplicates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = [][]string{{"A", "B", "C"}, {"E", "F"}}
+	if !reflect.DeepEqual(dups, want) {
+		t.Errorf("expected %v, got %v", want, dups)
+	}
+
+	pm["H"] = &Player{Name: "Bad", RemainingTeams: teams("AAA"), RemainingDoubleDowns: 1}
+	if _, err := pm.Duplicates(); err == nil {
+		t.Errorf("expected error for invalid player")
+	}
+}
+
+func TestPlayer_Store(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+
+	p, err := s.Player(ctx, "Player A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.History) != 2 || p.RemainingByes != 1 || p.RemainingDoubleDowns != 1 {
+		t.Errorf("unexpected player %v", p)
+	}
+	if picks := p.ContestPicks(BeatTheStreakContest); len(picks) != 1 || picks[0].Team.ID != "Alpha" || picks[0].Season.ID != "2019" {
+		t.Errorf("unexpected streak picks %v", picks)
+	}
+
+	all, err := s.Teams(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	teams, err := p.Remaining(all)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(teams) != 3 || teams[0].SchoolName != "Beta" || teams[2].SchoolName != "Delta East" {
+		t.Errorf("unexpected remaining teams %v", teams)
+	}
+	for _, team := range teams {
+		if all[team.SchoolName] != team {
+			t.Errorf("expected remaining team %s to be the Team from the map", team.SchoolName)
+		}
+	}
+	delete(all, "Beta")
+	if _, err := p.Remaining(all); err == nil {
+		t.Errorf("expected error for a team not in the map")
+	}
+
+	wt, err := p.WeekTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1 bye, 1 single, 1 double-down
+	if wt.Len() != 3 || wt.NumberOfPermutations().Int64() != 6 {
+		t.Errorf("unexpected week types %v", wt)
+	}
+
+	if err := p.RecordStreakPick(SeasonRef(s, 2019), 2, s.Ref(TeamsCollection, "Gamma")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.PutPlayers(ctx, map[string]*Player{"Player A": p}, true); err != nil {
+		t.Fatal(err)
+	}
+	p, err = s.Player(ctx, "Player A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.RemainingTeams) != 2 || len(p.ContestPicks(BeatTheStreakContest)) != 2 {
+		t.Errorf("expected pick to be stored, got %v", p)
+	}
+}
+
+func TestPlayer_RecordStreakPick(t *testing.T) {
+	s := NewMemoryStore()
+	season := SeasonRef(s, 2019)
+	ref := func(id string) *firestore.DocumentRef { return s.Ref(TeamsCollection, id) }
+	p := &Player{
+		Name:                 "P",
+		RemainingTeams:       []*firestore.DocumentRef{ref("A"), ref("B"), ref("C"), ref("D")},
+		RemainingByes:        1,
+		RemainingDoubleDowns: 1,
+	}
+
+	if err := p.RecordStreakPick(season, 1, ref("E")); err == nil {
+		t.Errorf("expected error picking a team not remaining")
+	}
+	if err := p.RecordStreakPick(season, 1, ref("A"), ref("A")); err == nil {
+		t.Errorf("expected error picking the same team twice")
+	}
+	if err := p.RecordStreakPick(season, 1, ref("A"), ref("B"), ref("C")); err == nil {
+		t.Errorf("expected error picking three teams")
+	}
+	if len(p.RemainingTeams) != 4 || len(p.History) != 0 {
+		t.Fatalf("expected failed picks to leave player unchanged, got %v", p)
+	}
+
+	if err := p.RecordStreakPick(season, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.RecordStreakPick(season, 2); err == nil {
+		t.Errorf("expected error using a second bye")
+	}
+	if err := p.RecordStreakPick(season, 2, ref("B"), ref("D")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.RecordStreakPick(season, 3, ref("A"), ref("C")); err == nil {
+		t.Errorf("expected error using a second double-down")
+	}
+	if err := p.RecordStreakPick(season, 3, ref("C")); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(p.RemainingTeams) != 1 || p.RemainingTeams[0].ID != "A" {
+		t.Errorf("expected only A remaining, got %v", p.RemainingTeams)
+	}
+	if p.RemainingByes != 0 || p.RemainingDoubleDowns != 0 {
+		t.Errorf("expected no byes or double-downs remaining, got %d and %d", p.RemainingByes, p.RemainingDoubleDowns)
+	}
+	picks := p.ContestPicks(BeatTheStreakContest)
+	if len(picks) != 4 || picks[0].Team != nil || picks[3].Week != 3 {
+		t.Errorf("unexpected picks %v", picks)
+	}
+	if wt, err := p.WeekTypes(); err != nil || wt.Len() != 1 {
+		t.Errorf("expected one week left, got %v (%v)", wt, err)
+	}
+	p.RemainingDoubleDowns = 1
+	if _, err := p.WeekTypes(); err == nil {
+		t.Errorf("expected error with too few teams for double-downs")
+	}
+	p.RemainingDoubleDowns = 0
+	p.RemainingByes = -1
+	if _, err := p.WeekTypes(); err == nil || !strings.Contains(err.Error(), "byes") {
+		t.Errorf("expected error naming negative byes, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package pickem
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// ponyOrder returns the Picks ordered by the given confidence values, from least confident (1 point) to most confident.
+func ponyOrder(picks Picks, confidence []int) Picks {
+	ordered := make(Picks, len(picks))
+	for i, c := range confidence {
+		ordered[c-1] = picks[i]
+	}
+	return ordered
+}
+
+// PonyExpectedPoints returns the expected score of Picks ordered by confidence, where the ith Pick is worth i+1 points.
+func PonyExpectedPoints(picks Picks) float64 {
+	e := 0.
+	for i, p := range picks {
+		e += float64(i+1) * p.Prob
+	}
+	return e
+}
+
+// PonyPicks picks the favorite in each game of a slate and orders the picks by confidence to maximize the expected score
+// of a pony (confidence-weighted) contest, where the least confident pick is worth 1 point and the most confident pick is
+// worth as many points as there are games.  Assigning more points to more probable picks maximizes the expected score.
+//
+// The Picks are returned in order of increasing confidence, along with the expected score.
+func PonyPicks(slate []*Matchup, predicter MatchupPredicter) (Picks, float64, error) {
+	picks, err := StraightUpPicks(slate, predicter)
+	if err != nil {
+		return nil, 0., err
+	}
+	sort.SliceStable(picks, func(i, j int) bool { return picks[i].Prob < picks[j].Prob })
+	return picks, PonyExpectedPoints(picks), nil
+}
+
+// ponySimulation holds simulated game outcomes for estimating the probability of reaching a target score.
+type ponySimulation struct {
+	// outcomes[s][i] is true if the first team won game i in simulation s.
+	outcomes [][]bool
+	target   float64
+}
+
+// probability returns the fraction of simulations in which the picks score at least the target.
+func (ps *ponySimulation) probability(pickTeam1 []bool, confidence []int) float64 {
+	hits := 0
+	for _, outcome := range ps.outcomes {
+		score := 0
+		for i, pick := range pickTeam1 {
+			if pick == outcome[i] {
+				score += confidence[i]
+			}
+		}
+		if float64(score) >= ps.target {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(ps.outcomes))
+}
+
+// RiskSeekingPonyPicks picks a team in each game of a slate and orders the picks by confidence to maximize the probability of
+// scoring at least the target in a pony contest, rather than the expected score.  A player who needs a big week to catch up
+// can improve their chances by taking underdogs or putting more points on riskier picks.
+//
+// The probability is estimated by simulating the week the given number of times with a seeded random number generator.
+// Starting from the picks that maximize the expected score, picks are switched to the other team and pairs of confidence
+// values are swapped as long as doing so improves the estimated probability.  The Picks are returned in order of increasing
+// confidence, along with the estimated probability of reaching the target.
+func RiskSeekingPonyPicks(slate []*Matchup, predicter MatchupPredicter, target float64, sims int, seed int64) (Picks, float64, error) {
+	if sims <= 0 {
+		return nil, 0., fmt.Errorf("number of simulations must be positive, got %d", sims)
+	}
+	probs, spreads, err := predictSlate(slate, predicter)
+	if err != nil {
+		return nil, 0., err
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	ps := &ponySimulation{outcomes: make([][]bool, sims), target: target}
+	for s := range ps.outcomes {
+		outcome := make([]bool, len(probs))
+		for i, p := range probs {
+			outcome[i] = rng.Float64() < p
+		}
+		ps.outcomes[s] = outcome
+	}
+
+	// Start from the picks with maximum expected score.
+	pickTeam1 := make([]bool, len(slate))
+	pickProbs := make([]float64, len(slate))
+	for i, p := range probs {
+		pickTeam1[i] = p >= .5
+		pickProbs[i] = p
+		if !pickTeam1[i] {
+			pickProbs[i] = 1 - p
+		}
+	}
+	order := make([]int, len(slate))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return pickProbs[order[i]] < pickProbs[order[j]] })
+	confidence := make([]int, len(slate))
+	for c, i := range order {
+		confidence[i] = c + 1
+	}
+
+	best := ps.probability(pickTeam1, confidence)
+	for improved := true; improved; {
+		improved = false
+		for i := range pickTeam1 {
+			pickTeam1[i] = !pickTeam1[i]
+			if p := ps.probability(pickTeam1, confidence); p > best {
+				best = p
+				improved = true
+			} else {
+				pickTeam1[i] = !pickTeam1[i]
+			}
+		}
+		for i := range confidence {
+			for j := i + 1; j < len(confidence); j++ {
+				confidence[i], confidence[j] = confidence[j], confidence[i]
+				if p := ps.probability(pickTeam1, confidence); p > best {
+					best = p
+					improved = true
+				} else {
+					confidence[i], confidence[j] = confidence[j], confidence[i]
+				}
+			}
+		}
+	}
+
+	picks := make(Picks, len(slate))
+	for i, m := range slate {
+		picks[i] = straightUpPick(m, probs[i], spreads[i], pickTeam1[i])
+	}
+	return ponyOrder(picks, confidence), best, nil
+}
@@ -0,0 +1,98 @@
+package pickem
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func ponyFixture() ([]*Matchup, tablePredicter) {
+	probs := []float64{.55, .9, .3, .75, .6, .45}
+	tp := make(tablePredicter)
+	slate := make([]*Matchup, len(probs))
+	for i, p := range probs {
+		slate[i] = NewMatchup(fakeTeam("Home"), fakeTeam("Road"), Home)
+		tp[teamPair{slate[i].Team1, slate[i].Team2}] = p
+	}
+	return slate, tp
+}
+
+func TestPonyPicks(t *testing.T) {
+	slate, tp := ponyFixture()
+	picks, points, err := PonyPicks(slate, tp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantProbs := []float64{.55, .55, .6, .7, .75, .9}
+	for i, p := range picks {
+		if math.Abs(p.Prob-wantProbs[i]) > 1e-12 {
+			t.Errorf("pick %d: expected probability %v, got %v", i, wantProbs[i], p.Prob)
+		}
+	}
+	wantPoints := 0.
+	for i, p := range wantProbs {
+		wantPoints += float64(i+1) * p
+	}
+	if math.Abs(points-wantPoints) > 1e-12 {
+		t.Errorf("expected %v points, got %v", wantPoints, points)
+	}
+	if picks[3].Team != slate[2].Team2 {
+		t.Errorf("expected road team of game 2 with confidence 4")
+	}
+}
+
+func TestRiskSeekingPonyPicks(t *testing.T) {
+	slate, tp := ponyFixture()
+	maxPoints := float64(len(slate) * (len(slate) + 1) / 2)
+
+	for _, target := range []float64{10, 17, 19, maxPoints} {
+		picks, p, err := RiskSeekingPonyPicks(slate, tp, target, 5000, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(picks) != len(slate) {
+			t.Fatalf("expected %d picks, got %d", len(slate), len(picks))
+		}
+
+		// Reproduce the estimate with the same simulated outcomes.
+		rng := rand.New(rand.NewSource(7))
+		ps := &ponySimulation{outcomes: make([][]bool, 5000), target: target}
+		for s := range ps.outcomes {
+			ps.outcomes[s] = make([]bool, len(slate))
+			for i, m := range slate {
+				ps.outcomes[s][i] = rng.Float64() < tp[teamPair{m.Team1, m.Team2}]
+			}
+		}
+		pickTeam1 := make([]bool, len(slate))
+		confidence := make([]int, len(slate))
+		for c, pick := range picks {
+			for i, m := range slate {
+				if pick.Matchup == m {
+					pickTeam1[i] = pick.Team == m.Team1
+					confidence[i] = c + 1
+				}
+			}
+		}
+		if got := ps.probability(pickTeam1, confidence); math.Abs(got-p) > 1e-12 {
+			t.Errorf("target %v: expected probability %v, got %v", target, got, p)
+		}
+
+		expected, _, _ := PonyPicks(slate, tp)
+		for i := range confidence {
+			for c, pick := range expected {
+				if pick.Matchup == slate[i] {
+					pickTeam1[i] = pick.Team == slate[i].Team1
+					confidence[i] = c + 1
+				}
+			}
+		}
+		if base := ps.probability(pickTeam1, confidence); p < base {
+			t.Errorf("target %v: expected probability at least %v, got %v", target, base, p)
+		}
+	}
+
+	if _, _, err := RiskSeekingPonyPicks(slate, tp, 10, 0, 7); err == nil {
+		t.Errorf("expected error for no simulations")
+	}
+}
@@ -4,10 +4,6 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"strconv"
-
-	"cloud.google.com/go/firestore"
-	"google.golang.org/api/iterator"
 )
 
 // byWeek sorts a collection of games by week
@@ -17,82 +13,89 @@ func (b byWeek) Len() int           { return len(b) }
 func (b byWeek) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
 func (b byWeek) Less(i, j int) bool { return b[i].Week < b[j].Week }
 
-// Matchups converts a team's schedule into Matchups.
-func Matchups(ctx context.Context, fs *firestore.Client, team string, season int, startWeek int) ([]*Matchup, error) {
+// Matchups converts a team's schedule into Matchups, one per week in order starting with startWeek.  The first team of every
+// Matchup is the requested team, with the location relative to that team as determined by GameLocation.
+//
+// Weeks without a game are bye weeks, which are Matchups with a nil opponent.  The schedule runs through the team's last
+// game of the season.  If startWeek is less than 1, the schedule starts with the team's first game.  An error is returned
+// if the team plays more than once in a week.
+func Matchups(ctx context.Context, s Store, team string, season int, startWeek int) ([]*Matchup, error) {
 	var t *Team
 	var err error
-	if t, err = LookupTeam(ctx, fs, team); err != nil {
+	if t, err = LookupTeam(ctx, s, team); err != nil {
 		return nil, err
 	}
 
-	seasonRef := fs.Collection("seasons").Doc(strconv.Itoa(season))
-	teamRef := fs.Collection("xteams").Doc(t.SchoolName)
+	teamRef := s.Ref(TeamsCollection, t.SchoolName)
+	gameMap, err := s.Games(ctx, GameQuery{Season: season, MinWeek: startWeek, Team: teamRef.ID})
+	if err != nil {
+		return nil, err
+	}
+	games := make([]*Game, 0, len(gameMap))
+	for _, game := range gameMap {
+		games = append(games, game)
+	}
+	if len(games) == 0 {
+		return []*Matchup{}, nil
+	}
+
+	sort.Stable(byWeek(games))
+	week := startWeek
+	if week < 1 {
+		week = games[0].Week
+	}
 
-	games := make([]*Game, 0)
-	fmt.Println(seasonRef.Path)
-	fmt.Println(startWeek)
-	fmt.Println(teamRef.Path)
-	homeGameItr := fs.Collection("xgames").Where("season", "==", seasonRef).Where("week", ">=", startWeek).Where("home_team", "==", teamRef).Documents(ctx)
-	defer homeGameItr.Stop()
-	for {
-		doc, err := homeGameItr.Next()
-		if err == iterator.Done {
-			break
+	teams := map[string]*Team{t.SchoolName: t}
+	teamByID := func(id string) (*Team, error) {
+		if tm, ok := teams[id]; ok {
+			return tm, nil
 		}
+		tm, err := s.Team(ctx, id)
 		if err != nil {
 			return nil, err
 		}
-		var game Game
-		if err := doc.DataTo(&game); err != nil {
-			return nil, err
-		}
-		games = append(games, &game)
+		teams[id] = tm
+		return tm, nil
 	}
-
-	awayGameItr := fs.Collection("xgames").Where("season", "==", seasonRef).Where("week", ">=", startWeek).Where("away_team", "==", teamRef).Documents(ctx)
-	defer awayGameItr.Stop()
-	for {
-		doc, err := awayGameItr.Next()
-		if err == iterator.Done {
-			break
+	venues := make(map[string]*Venue)
+	venueByID := func(id string) (*Venue, error) {
+		if v, ok := venues[id]; ok {
+			return v, nil
 		}
+		v, err := venueOrNil(ctx, s, id)
 		if err != nil {
 			return nil, err
 		}
-		var game Game
-		if err := doc.DataTo(&game); err != nil {
-			return nil, err
-		}
-		games = append(games, &game)
+		venues[id] = v
+		return v, nil
 	}
 
-	sort.Sort(byWeek(games))
-	matchups := make([]*Matchup, len(games))
-	for i, game := range games {
-		var t1, t2 Team
-		doc, err := game.HomeTeam.Get(ctx)
-		if err != nil {
-			return nil, err
+	matchups := make([]*Matchup, 0, len(games))
+	for _, game := range games {
+		if game.Week < week {
+			return nil, fmt.Errorf("team '%s' plays more than once in week %d", t.SchoolName, game.Week)
 		}
-		if err := doc.DataTo(&t1); err != nil {
-			return nil, err
+		for ; week < game.Week; week++ {
+			matchups = append(matchups, &Matchup{Team1: t, Team2: nil, Location: Neutral})
 		}
-		doc, err = game.AwayTeam.Get(ctx)
+		week = game.Week + 1
+
+		oppID := game.HomeTeam.ID
+		if oppID == t.SchoolName {
+			oppID = game.AwayTeam.ID
+		}
+		opp, err := teamByID(oppID)
 		if err != nil {
 			return nil, err
 		}
-		if err := doc.DataTo(&t2); err != nil {
+		loc, err := gameLocation(game, t, teamByID, venueByID)
+		if err != nil {
 			return nil, err
 		}
-		loc := Home
-		if game.NeutralSite {
-			loc = Neutral
-		}
-		matchups[i] = &Matchup{Team1: &t1, Team2: &t2, Location: loc}
+		matchups = append(matchups, &Matchup{Team1: t, Team2: opp, Location: loc})
 	}
 
 	return matchups, nil
-
 }
 
 func loc(locTeam string) RelativeLocation {
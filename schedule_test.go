@@ -0,0 +1,88 @@
+package pickem
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMatchups(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+
+	type want struct {
+		team1 string
+		team2 string
+		loc   RelativeLocation
+	}
+	tests := []struct {
+		name      string
+		team      string
+		season    int
+		startWeek int
+		want      []want
+		wantErr   bool
+	}{
+		{name: "full season",
+			team: "Alpha", season: 2019, startWeek: 1,
+			want: []want{{"Alpha", "Beta", Home}, {"Alpha", "Gamma", Near}, {"Alpha", "", Neutral}, {"Alpha", "Gamma", Home}, {"Alpha", "Beta", Away}}},
+		{name: "from week 3",
+			team: "ALP", season: 2019, startWeek: 3,
+			want: []want{{"Alpha", "", Neutral}, {"Alpha", "Gamma", Home}, {"Alpha", "Beta", Away}}},
+		{name: "all weeks",
+			team: "Gamma Tech", season: 2019, startWeek: 0,
+			want: []want{{"Gamma", "Alpha", Far}, {"Gamma", "Beta", Away}, {"Gamma", "Alpha", Away}}},
+		{name: "other season",
+			team: "Alpha U", season: 2018, startWeek: 1,
+			want: []want{{"Alpha", "Gamma", Away}}},
+		{name: "no games",
+			team: "Delta East", season: 2019, startWeek: 1,
+			want: []want{}},
+		{name: "unknown team",
+			team: "Omega", season: 2019, startWeek: 1,
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Matchups(ctx, s, tt.team, tt.season, tt.startWeek)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Matchups() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Matchups() returned %d matchups, want %d", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				team2 := ""
+				if got[i].Team2 != nil {
+					team2 = got[i].Team2.SchoolName
+				}
+				if got[i].Team1.SchoolName != w.team1 || team2 != w.team2 || got[i].Location != w.loc {
+					t.Errorf("Matchups()[%d] = %s v %s (%v), want %s v %s (%v)", i, got[i].Team1.SchoolName, team2, got[i].Location, w.team1, w.team2, w.loc)
+				}
+				if got[i].Team1 != got[0].Team1 {
+					t.Errorf("Matchups()[%d] has a different first team pointer", i)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchups_DoubleBooked(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+	extra := map[string]*Game{"106": {
+		Season:   SeasonRef(s, 2019),
+		Week:     1,
+		HomeTeam: s.Ref(TeamsCollection, "Gamma"),
+		AwayTeam: s.Ref(TeamsCollection, "Alpha"),
+	}}
+	if err := s.PutGames(ctx, extra, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Matchups(ctx, s, "Alpha", 2019, 1); err == nil {
+		t.Errorf("expected error for a team playing twice in a week")
+	}
+}
@@ -0,0 +1,6 @@
+package pickem
+
+// A Season represents a year of play.  Season documents are keyed by the year, as made by SeasonRef, and Games refer to them.
+type Season struct {
+	Year int `json:"year" firestore:"year"`
+}
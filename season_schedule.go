@@ -0,0 +1,185 @@
+package pickem
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// SeasonSchedule is a grid of Matchups for every team that plays in a season, indexed by team and week.
+//
+// Every Matchup in a team's schedule has that team as the first team, with the location relative to that team as determined
+// by GameLocation and the Conditions of the game as measured by GameConditions, swapped if the team is the away team.  Weeks in which a team does not play are bye weeks, which are Matchups with a nil opponent.  Teams are
+// taken from the map given to NewSeasonSchedule, so every Matchup refers to the same *Team for the same team.
+type SeasonSchedule struct {
+	Season int
+	// FirstWeek and LastWeek are the first and last weeks in which any team plays.
+	FirstWeek int
+	LastWeek  int
+
+	teams []*Team
+	byID  map[string]*Team
+	grid  map[*Team][]*Matchup
+}
+
+// NewSeasonSchedule loads every regular season Game of a season and every Venue from the Store in one query each and arranges
+// them into a SeasonSchedule.  Postseason games are left out, because their week numbers are not comparable to the regular
+// season.  An error is returned if a team plays more than once in a week or a game refers to an unknown team.
+//
+// The teams map looks up the Teams referenced by the Games by document ID, as returned by Store.Teams.  Models and BestStreak
+// look up teams by pointer, so pass the same map used to fit models and to look up a Player's remaining teams.
+func NewSeasonSchedule(ctx context.Context, s Store, season int, teams map[string]*Team) (*SeasonSchedule, error) {
+	gameMap, err := s.Games(ctx, GameQuery{Season: season})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(gameMap))
+	regular := make(map[string]*Game, len(gameMap))
+	for id, g := range gameMap {
+		if !g.Postseason {
+			ids = append(ids, id)
+			regular[id] = g
+		}
+	}
+	sort.Strings(ids)
+
+	ss := &SeasonSchedule{
+		Season: season,
+		teams:  make([]*Team, 0),
+		byID:   make(map[string]*Team),
+		grid:   make(map[*Team][]*Matchup),
+	}
+	if len(ids) == 0 {
+		return ss, nil
+	}
+
+	venues, err := s.Venues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	venueByID := func(id string) (*Venue, error) { return venues[id], nil }
+	teamByID := func(id string) (*Team, error) {
+		if t, ok := ss.byID[id]; ok {
+			return t, nil
+		}
+		t, ok := teams[id]
+		if !ok {
+			return nil, fmt.Errorf("team '%s' not found", id)
+		}
+		ss.byID[id] = t
+		ss.teams = append(ss.teams, t)
+		return t, nil
+	}
+
+	ss.FirstWeek, ss.LastWeek = gameMap[ids[0]].Week, gameMap[ids[0]].Week
+	for _, id := range ids {
+		g := gameMap[id]
+		if g.Week < ss.FirstWeek {
+			ss.FirstWeek = g.Week
+		}
+		if g.Week > ss.LastWeek {
+			ss.LastWeek = g.Week
+		}
+	}
+	nWeeks := ss.LastWeek - ss.FirstWeek + 1
+	conditions, err := gameConditions(regular, teams, venues)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		g := gameMap[id]
+		home, err := teamByID(g.HomeTeam.ID)
+		if err != nil {
+			return nil, err
+		}
+		away, err := teamByID(g.AwayTeam.ID)
+		if err != nil {
+			return nil, err
+		}
+		for i, pair := range [][2]*Team{{home, away}, {away, home}} {
+			t, opp := pair[0], pair[1]
+			if _, ok := ss.grid[t]; !ok {
+				ss.grid[t] = make([]*Matchup, nWeeks)
+			}
+			w := g.Week - ss.FirstWeek
+			if ss.grid[t][w] != nil {
+				return nil, fmt.Errorf("team '%s' plays more than once in week %d", t.SchoolName, g.Week)
+			}
+			loc, err := gameLocation(g, t, teamByID, venueByID)
+			if err != nil {
+				return nil, err
+			}
+			c := conditions[id]
+			if i == 1 {
+				c = c.Swap()
+			}
+			ss.grid[t][w] = &Matchup{Team1: t, Team2: opp, Location: loc, Conditions: c}
+		}
+	}
+
+	for t, schedule := range ss.grid {
+		for w, m := range schedule {
+			if m == nil {
+				schedule[w] = &Matchup{Team1: t, Team2: nil, Location: Neutral}
+			}
+		}
+	}
+	sort.Slice(ss.teams, func(i, j int) bool { return ss.teams[i].SchoolName < ss.teams[j].SchoolName })
+	return ss, nil
+}
+
+// Teams returns the teams that play in the season, sorted by SchoolName.
+func (ss *SeasonSchedule) Teams() []*Team {
+	teams := make([]*Team, len(ss.teams))
+	copy(teams, ss.teams)
+	return teams
+}
+
+// Team returns the team with the given document ID, or nil if the team does not play in the season.
+func (ss *SeasonSchedule) Team(id string) *Team {
+	return ss.byID[id]
+}
+
+// Matchup returns the team's Matchup in the given week, or nil if the team does not play in the season or the week is
+// outside of the season.
+func (ss *SeasonSchedule) Matchup(team *Team, week int) *Matchup {
+	schedule, ok := ss.grid[team]
+	if !ok || week < ss.FirstWeek || week > ss.LastWeek {
+		return nil
+	}
+	return schedule[week-ss.FirstWeek]
+}
+
+// IsBye returns true if the team plays in the season but not in the given week.
+func (ss *SeasonSchedule) IsBye(team *Team, week int) bool {
+	m := ss.Matchup(team, week)
+	return m != nil && m.Team2 == nil
+}
+
+// Schedule returns the team's Matchups for every week from startWeek through the last week of the season, or nil if the
+// team does not play in the season.  Weeks before the first week of the season are bye weeks.
+func (ss *SeasonSchedule) Schedule(team *Team, startWeek int) []*Matchup {
+	schedule, ok := ss.grid[team]
+	if !ok {
+		return nil
+	}
+	out := make([]*Matchup, 0)
+	for week := startWeek; week <= ss.LastWeek; week++ {
+		if week < ss.FirstWeek {
+			out = append(out, &Matchup{Team1: team, Team2: nil, Location: Neutral})
+			continue
+		}
+		out = append(out, schedule[week-ss.FirstWeek])
+	}
+	return out
+}
+
+// Schedules returns the schedule of every team from startWeek, in the form expected by BestStreak.
+func (ss *SeasonSchedule) Schedules(startWeek int) map[*Team][]*Matchup {
+	schedules := make(map[*Team][]*Matchup, len(ss.grid))
+	for t := range ss.grid {
+		schedules[t] = ss.Schedule(t, startWeek)
+	}
+	return schedules
+}
@@ -0,0 +1,217 @@
+package pickem
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// fixtureTeams loads every Team from the Store.
+func fixtureTeams(t *testing.T, s Store) map[string]*Team {
+	t.Helper()
+	teams, err := s.Teams(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return teams
+}
+
+func TestNewSeasonSchedule(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+	ss, err := NewSeasonSchedule(ctx, s, 2019, fixtureTeams(t, s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ss.FirstWeek != 1 || ss.LastWeek != 5 {
+		t.Errorf("expected weeks 1 to 5, got %d to %d", ss.FirstWeek, ss.LastWeek)
+	}
+	teams := ss.Teams()
+	if len(teams) != 3 || teams[0].SchoolName != "Alpha" || teams[1].SchoolName != "Beta" || teams[2].SchoolName != "Gamma" {
+		t.Fatalf("expected Alpha, Beta, Gamma, got %v", teams)
+	}
+	alpha, beta, gamma := ss.Team("Alpha"), ss.Team("Beta"), ss.Team("Gamma")
+	if alpha != teams[0] || ss.Team("Delta East") != nil {
+		t.Errorf("unexpected Team lookups")
+	}
+
+	type cell struct {
+		opp *Team
+		loc RelativeLocation
+	}
+	want := map[*Team][]cell{
+		alpha: {{beta, Home}, {gamma, Near}, {nil, Neutral}, {gamma, Home}, {beta, Away}},
+		beta:  {{alpha, Away}, {nil, Neutral}, {gamma, Home}, {nil, Neutral}, {alpha, Home}},
+		gamma: {{nil, Neutral}, {alpha, Far}, {beta, Away}, {alpha, Away}, {nil, Neutral}},
+	}
+	for team, cells := range want {
+		for i, c := range cells {
+			week := i + 1
+			m := ss.Matchup(team, week)
+			if m.Team1 != team || m.Team2 != c.opp || m.Location != c.loc {
+				t.Errorf("%s week %d: got %v, want %v (%v)", team.SchoolName, week, m, c.opp, c.loc)
+			}
+			if ss.IsBye(team, week) != (c.opp == nil) {
+				t.Errorf("%s week %d: IsBye() = %v", team.SchoolName, week, ss.IsBye(team, week))
+			}
+		}
+	}
+	if ss.Matchup(alpha, 6) != nil || ss.Matchup(fakeTeam("X"), 1) != nil || ss.IsBye(alpha, 0) {
+		t.Errorf("expected no matchups outside of the season")
+	}
+
+	schedule := ss.Schedule(gamma, 2)
+	if len(schedule) != 4 || schedule[0] != ss.Matchup(gamma, 2) || schedule[3].Team2 != nil {
+		t.Errorf("unexpected schedule from week 2: %v", schedule)
+	}
+	schedule = ss.Schedule(alpha, 0)
+	if len(schedule) != 6 || schedule[0].Team2 != nil || schedule[0].Team1 != alpha || schedule[1] != ss.Matchup(alpha, 1) {
+		t.Errorf("unexpected schedule from week 0: %v", schedule)
+	}
+	if len(ss.Schedule(alpha, 7)) != 0 || ss.Schedule(fakeTeam("X"), 1) != nil {
+		t.Errorf("unexpected schedules outside of the season")
+	}
+	if schedules := ss.Schedules(1); len(schedules) != 3 || len(schedules[beta]) != 5 {
+		t.Errorf("unexpected Schedules(): %v", schedules)
+	}
+
+	// Conditions are oriented to the team whose schedule they are in.
+	games, err := s.Games(ctx, GameQuery{Season: 2019})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conditions, err := GameConditions(ctx, s, games)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := ss.Matchup(alpha, 1).Conditions; c != conditions["101"] {
+		t.Errorf("expected home conditions %+v, got %+v", conditions["101"], c)
+	}
+	if c := ss.Matchup(beta, 1).Conditions; c != conditions["101"].Swap() || c.Team1.Travel == 0 {
+		t.Errorf("expected away conditions %+v, got %+v", conditions["101"].Swap(), c)
+	}
+
+	// The grid agrees with the per-team Matchups.
+	matchups, err := Matchups(ctx, s, "Alpha", 2019, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range matchups {
+		got := ss.Matchup(alpha, i+1)
+		if got.Location != m.Location || (got.Team2 == nil) != (m.Team2 == nil) || (m.Team2 != nil && got.Team2.SchoolName != m.Team2.SchoolName) {
+			t.Errorf("week %d: grid has %v, Matchups has %v", i+1, got, m)
+		}
+	}
+
+	empty, err := NewSeasonSchedule(ctx, s, 2000, fixtureTeams(t, s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(empty.Teams()) != 0 {
+		t.Errorf("expected no teams in an empty season")
+	}
+}
+
+func TestNewSeasonSchedule_DoubleBooked(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+	extra := map[string]*Game{"106": {
+		Season:   SeasonRef(s, 2019),
+		Week:     1,
+		HomeTeam: s.Ref(TeamsCollection, "Gamma"),
+		AwayTeam: s.Ref(TeamsCollection, "Alpha"),
+	}}
+	if err := s.PutGames(ctx, extra, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSeasonSchedule(ctx, s, 2019, fixtureTeams(t, s)); err == nil {
+		t.Errorf("expected error for a team playing twice in a week")
+	}
+
+	extra["106"].Postseason = true
+	if err := s.PutGames(ctx, extra, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSeasonSchedule(ctx, s, 2019, fixtureTeams(t, s)); err != nil {
+		t.Errorf("expected postseason games to be left out, got %v", err)
+	}
+}
+
+func TestNewSeasonSchedule_UnknownTeam(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+	extra := map[string]*Game{"106": {
+		Season:   SeasonRef(s, 2019),
+		Week:     6,
+		HomeTeam: s.Ref(TeamsCollection, "Omega"),
+		AwayTeam: s.Ref(TeamsCollection, "Alpha"),
+	}}
+	if err := s.PutGames(ctx, extra, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSeasonSchedule(ctx, s, 2019, fixtureTeams(t, s)); err == nil {
+		t.Errorf("expected error for a game with an unknown team")
+	}
+}
+
+func TestNewSeasonSchedule_BestStreak(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+	teams := fixtureTeams(t, s)
+	games, err := s.Games(ctx, GameQuery{Season: 2019})
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := FitRatings(games, teams, RatingsOptions{Ridge: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss, err := NewSeasonSchedule(ctx, s, 2019, teams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ss.Team("Beta") != teams["Beta"] {
+		t.Errorf("expected the schedule to use the given teams")
+	}
+
+	// Delta East does not play in 2019, so the player picks Beta and Gamma over the last three weeks with a bye.
+	p, err := s.Player(ctx, "Player A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.RemainingTeams = p.RemainingTeams[:2]
+	p.RemainingDoubleDowns = 0
+	remaining, err := p.Remaining(teams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weekTypes, err := p.WeekTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	streak, prob, err := BestStreak(remaining, weekTypes, ss.Schedules(3), model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prob <= 0 || prob > 1 {
+		t.Errorf("expected a probability, got %v", prob)
+	}
+
+	// The streak's probability comes from the model's predictions of the schedule's Matchups for the same *Team values.
+	want := 1.
+	for w := 0; w < streak.NumWeeks(); w++ {
+		for _, team := range streak.GetWeek(w) {
+			if team != teams[team.SchoolName] {
+				t.Errorf("week %d: expected a team from the given map, got %v", w+3, team)
+			}
+			pr, _, err := model.Predict(*ss.Matchup(team, w+3))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want *= pr
+		}
+	}
+	if math.Abs(prob-want) > 1e-12 {
+		t.Errorf("streak probability = %v, want %v from the schedule", prob, want)
+	}
+}
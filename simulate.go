@@ -0,0 +1,190 @@
+package pickem
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// SeasonSimulation holds the outcomes of simulating a season of Matchups many times.
+type SeasonSimulation struct {
+	// Seasons is the number of simulated seasons.
+	Seasons int
+	// Matchups are the simulated Matchups, in the order given to SimulateSeason.
+	Matchups []*Matchup
+	// Teams are the teams playing in the Matchups, in order of first appearance.
+	Teams []*Team
+
+	// gameWins[i] counts the seasons in which the first team of Matchup i won.
+	gameWins []int
+	// winTotals[t][w] counts the seasons in which team t won exactly w games.
+	winTotals map[*Team][]int
+	// finishes[t][p] counts the seasons in which team t finished in place p of its conference (0 is first place).
+	finishes map[*Team][]int
+	// conferences groups teams by conference.
+	conferences map[string][]*Team
+}
+
+// SimulateSeason simulates a season of Matchups n times, picking the winner of each Matchup at random with the probability
+// given by the predicter.  Matchups with a missing team (bye weeks) are ignored.
+//
+// Conference standings are determined by the number of wins in games between teams of the same conference.  Ties are
+// broken by total wins, then at random.  The simulation is deterministic for a given seed.
+func SimulateSeason(matchups []*Matchup, predicter MatchupPredicter, n int, seed int64) (*SeasonSimulation, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("number of seasons to simulate must be positive, got %d", n)
+	}
+
+	sim := &SeasonSimulation{
+		Seasons:     n,
+		Matchups:    matchups,
+		Teams:       make([]*Team, 0),
+		gameWins:    make([]int, len(matchups)),
+		winTotals:   make(map[*Team][]int),
+		finishes:    make(map[*Team][]int),
+		conferences: make(map[string][]*Team),
+	}
+
+	probs := make([]float64, len(matchups))
+	games := make(map[*Team]int)
+	for i, m := range matchups {
+		if m == nil || m.Team1 == nil || m.Team2 == nil {
+			continue
+		}
+		p, _, err := predicter.Predict(*m)
+		if err != nil {
+			return nil, err
+		}
+		probs[i] = p
+		for _, t := range []*Team{m.Team1, m.Team2} {
+			if _, ok := games[t]; !ok {
+				sim.Teams = append(sim.Teams, t)
+			}
+			games[t]++
+		}
+	}
+
+	for _, t := range sim.Teams {
+		sim.winTotals[t] = make([]int, games[t]+1)
+		if t.Conference != nil {
+			sim.conferences[*t.Conference] = append(sim.conferences[*t.Conference], t)
+		}
+	}
+	for _, teams := range sim.conferences {
+		for _, t := range teams {
+			sim.finishes[t] = make([]int, len(teams))
+		}
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	wins := make(map[*Team]int)
+	confWins := make(map[*Team]int)
+	for s := 0; s < n; s++ {
+		for _, t := range sim.Teams {
+			wins[t] = 0
+			confWins[t] = 0
+		}
+
+		for i, m := range matchups {
+			if m == nil || m.Team1 == nil || m.Team2 == nil {
+				continue
+			}
+			winner := m.Team2
+			if rng.Float64() < probs[i] {
+				winner = m.Team1
+				sim.gameWins[i]++
+			}
+			wins[winner]++
+			if sameConference(m.Team1, m.Team2) {
+				confWins[winner]++
+			}
+		}
+
+		for _, t := range sim.Teams {
+			sim.winTotals[t][wins[t]]++
+		}
+		sim.rankConferences(rng, wins, confWins)
+	}
+
+	return sim, nil
+}
+
+func sameConference(t1, t2 *Team) bool {
+	return t1.Conference != nil && t2.Conference != nil && *t1.Conference == *t2.Conference
+}
+
+// rankConferences records the finishing place of each team in its conference for one simulated season.
+func (sim *SeasonSimulation) rankConferences(rng *rand.Rand, wins, confWins map[*Team]int) {
+	// Conferences are ranked in a fixed order so the random tiebreakers are reproducible.
+	names := make([]string, 0, len(sim.conferences))
+	for name := range sim.conferences {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		teams := make([]*Team, len(sim.conferences[name]))
+		copy(teams, sim.conferences[name])
+		rng.Shuffle(len(teams), func(i, j int) { teams[i], teams[j] = teams[j], teams[i] })
+		sort.SliceStable(teams, func(i, j int) bool {
+			if confWins[teams[i]] != confWins[teams[j]] {
+				return confWins[teams[i]] > confWins[teams[j]]
+			}
+			return wins[teams[i]] > wins[teams[j]]
+		})
+		for place, t := range teams {
+			sim.finishes[t][place]++
+		}
+	}
+}
+
+// GameWinFrequency returns the fraction of simulated seasons in which the first team of the ith Matchup won.
+func (sim *SeasonSimulation) GameWinFrequency(i int) float64 {
+	return float64(sim.gameWins[i]) / float64(sim.Seasons)
+}
+
+// WinTotalDistribution returns the fraction of simulated seasons in which the team won exactly w games, for w from zero
+// to the number of games the team played.  It returns nil if the team did not play.
+func (sim *SeasonSimulation) WinTotalDistribution(team *Team) []float64 {
+	counts, ok := sim.winTotals[team]
+	if !ok {
+		return nil
+	}
+	dist := make([]float64, len(counts))
+	for w, c := range counts {
+		dist[w] = float64(c) / float64(sim.Seasons)
+	}
+	return dist
+}
+
+// ExpectedWins returns the mean number of wins of the team over the simulated seasons.
+func (sim *SeasonSimulation) ExpectedWins(team *Team) float64 {
+	e := 0.
+	for w, p := range sim.WinTotalDistribution(team) {
+		e += float64(w) * p
+	}
+	return e
+}
+
+// ConferenceFinishDistribution returns the fraction of simulated seasons in which the team finished in each place of its
+// conference, starting with first place.  It returns nil if the team has no conference.
+func (sim *SeasonSimulation) ConferenceFinishDistribution(team *Team) []float64 {
+	counts, ok := sim.finishes[team]
+	if !ok {
+		return nil
+	}
+	dist := make([]float64, len(counts))
+	for p, c := range counts {
+		dist[p] = float64(c) / float64(sim.Seasons)
+	}
+	return dist
+}
+
+// ConferenceChampionProbability returns the fraction of simulated seasons in which the team finished first in its conference.
+func (sim *SeasonSimulation) ConferenceChampionProbability(team *Team) float64 {
+	dist := sim.ConferenceFinishDistribution(team)
+	if len(dist) == 0 {
+		return 0.
+	}
+	return dist[0]
+}
@@ -0,0 +1,107 @@
+package pickem
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func conferenceTeam(name, conference string) *Team {
+	t := fakeTeam(name)
+	t.Conference = &conference
+	return t
+}
+
+func TestSimulateSeason(t *testing.T) {
+	teamA := conferenceTeam("A", "East")
+	teamB := conferenceTeam("B", "East")
+	teamC := conferenceTeam("C", "East")
+	teamD := fakeTeam("D")
+
+	tp := tablePredicter{
+		teamPair{teamA, teamB}: .8,
+		teamPair{teamB, teamC}: .6,
+		teamPair{teamC, teamA}: .3,
+		teamPair{teamA, teamD}: 1,
+		teamPair{teamD, teamC}: .5,
+	}
+	matchups := []*Matchup{
+		NewMatchup(teamA, teamB, Home),
+		NewMatchup(teamB, teamC, Home),
+		NewMatchup(teamC, teamA, Home),
+		NewMatchup(teamA, teamD, Home),
+		NewMatchup(teamD, teamC, Neutral),
+		NewMatchup(teamD, nil, Neutral),
+	}
+
+	sim, err := SimulateSeason(matchups, tp, 20000, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sim.Teams) != 4 {
+		t.Errorf("expected 4 teams, got %d", len(sim.Teams))
+	}
+
+	for i, m := range matchups[:5] {
+		want := tp[teamPair{m.Team1, m.Team2}]
+		if got := sim.GameWinFrequency(i); math.Abs(got-want) > .02 {
+			t.Errorf("GameWinFrequency(%d) = %v, want %v", i, got, want)
+		}
+	}
+	if got := sim.GameWinFrequency(5); got != 0 {
+		t.Errorf("expected bye to be ignored, got %v", got)
+	}
+
+	dist := sim.WinTotalDistribution(teamA)
+	if len(dist) != 4 {
+		t.Errorf("expected A to play 3 games, got %d", len(dist)-1)
+	}
+	if dist[0] != 0 {
+		t.Errorf("expected A to always beat D, got %v", dist)
+	}
+	sum := 0.
+	for _, p := range dist {
+		sum += p
+	}
+	if math.Abs(sum-1) > 1e-12 {
+		t.Errorf("expected distribution to sum to 1, got %v", sum)
+	}
+	if got, want := sim.ExpectedWins(teamA), .8+.7+1; math.Abs(got-want) > .02 {
+		t.Errorf("ExpectedWins(A) = %v, want %v", got, want)
+	}
+
+	if sim.ConferenceFinishDistribution(teamD) != nil {
+		t.Errorf("expected no conference finishes for D")
+	}
+	champ := 0.
+	for _, team := range []*Team{teamA, teamB, teamC} {
+		finishes := sim.ConferenceFinishDistribution(team)
+		if len(finishes) != 3 {
+			t.Errorf("expected 3 places, got %v", finishes)
+		}
+		champ += sim.ConferenceChampionProbability(team)
+	}
+	if math.Abs(champ-1) > 1e-12 {
+		t.Errorf("expected champion probabilities to sum to 1, got %v", champ)
+	}
+	if sim.ConferenceChampionProbability(teamA) < sim.ConferenceChampionProbability(teamC) {
+		t.Errorf("expected A to be more likely champion than C")
+	}
+
+	// Same seed, same results
+	sim2, err := SimulateSeason(matchups, tp, 20000, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sim, sim2) {
+		t.Errorf("expected identical simulations with identical seeds")
+	}
+
+	if _, err := SimulateSeason(matchups, tp, 0, 42); err == nil {
+		t.Errorf("expected error simulating no seasons")
+	}
+	if _, err := SimulateSeason([]*Matchup{NewMatchup(teamA, fakeTeam("Z"), Home)}, tp, 1, 42); err == nil {
+		t.Errorf("expected error from predicter")
+	}
+}
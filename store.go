@@ -0,0 +1,160 @@
+package pickem
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"cloud.google.com/go/firestore"
+)
+
+// Names of the collections in which Pick 'Em documents are stored.
+const (
+	TeamsCollection   = "xteams"
+	GamesCollection   = "xgames"
+	VenuesCollection  = "xvenues"
+	SeasonsCollection = "seasons"
+	PlayersCollection = "players"
+)
+
+// ErrNotFound is returned by a Store when a requested document does not exist.
+var ErrNotFound = errors.New("document not found")
+
+// ErrExists is returned by a Store when a document would be written without overwriting and the document already exists.
+var ErrExists = errors.New("document already exists")
+
+// GameQuery filters the Games returned by a Store.  Zero values match everything.
+type GameQuery struct {
+	// Season is the year of the season in which the Games are played.
+	Season int
+	// MinWeek is the earliest week of the season to return.
+	MinWeek int
+	// Team is the document ID of a team playing in the Games, either at home or on the road.
+	Team string
+}
+
+// Store describes persistent storage for Pick 'Em data.
+//
+// Documents are identified by their ID within a collection (see TeamsCollection, etc.).  References between
+// documents are stored as *firestore.DocumentRef values, but only the ID of the reference is meaningful to a
+// Store that is not backed by Firestore.  Use Ref to make references that are valid for a given Store.
+//
+// Methods that return a single document return an error wrapping ErrNotFound if the document does not exist.
+// Methods that write documents return an error wrapping ErrExists if overwrite is false and a document already exists.
+type Store interface {
+	// Ref returns a reference to the document with the given ID in the given collection.
+	Ref(collection, id string) *firestore.DocumentRef
+
+	Team(ctx context.Context, id string) (*Team, error)
+	Teams(ctx context.Context) (map[string]*Team, error)
+	TeamsByAbbreviation(ctx context.Context, abbreviation string) (map[string]*Team, error)
+	TeamsByName(ctx context.Context, name string) (map[string]*Team, error)
+	PutTeams(ctx context.Context, teams map[string]*Team, overwrite bool) error
+	// SetHomeVenues sets the home venue of each team, keyed by team document ID, without changing the rest of the team documents.
+	SetHomeVenues(ctx context.Context, venues map[string]*firestore.DocumentRef) error
+
+	Venue(ctx context.Context, id string) (*Venue, error)
+	Venues(ctx context.Context) (map[string]*Venue, error)
+	PutVenues(ctx context.Context, venues map[string]*Venue, overwrite bool) error
+
+	Games(ctx context.Context, q GameQuery) (map[string]*Game, error)
+	PutGames(ctx context.Context, games map[string]*Game, overwrite bool) error
+
+	Season(ctx context.Context, id string) (*Season, error)
+	Seasons(ctx context.Context) (map[string]*Season, error)
+	PutSeasons(ctx context.Context, seasons map[string]*Season, overwrite bool) error
+
+	Player(ctx context.Context, id string) (*Player, error)
+	Players(ctx context.Context) (map[string]*Player, error)
+	PutPlayers(ctx context.Context, players map[string]*Player, overwrite bool) error
+
+	// Close releases any resources held by the Store.
+	Close() error
+}
+
+// SeasonRef returns a reference to the given season in a Store.
+func SeasonRef(s Store, season int) *firestore.DocumentRef {
+	return s.Ref(SeasonsCollection, strconv.Itoa(season))
+}
+
+// A refMapper converts a reference to a document in the given collection into an equivalent reference.
+type refMapper func(collection string, ref *firestore.DocumentRef) *firestore.DocumentRef
+
+// detachedRef makes a reference that is not tied to any Firestore client.
+func detachedRef(collection, id string) *firestore.DocumentRef {
+	return &firestore.DocumentRef{
+		Parent: &firestore.CollectionRef{Path: collection, ID: collection},
+		Path:   collection + "/" + id,
+		ID:     id,
+	}
+}
+
+// detach implements refMapper by converting references to detached references.
+func detach(collection string, ref *firestore.DocumentRef) *firestore.DocumentRef {
+	if ref == nil {
+		return nil
+	}
+	return detachedRef(collection, ref.ID)
+}
+
+// mapRefs returns a copy of the Team with references converted by f.
+func (t Team) mapRefs(f refMapper) *Team {
+	t.HomeVenue = f(VenuesCollection, t.HomeVenue)
+	return &t
+}
+
+// mapRefs returns a copy of the Game with references converted by f.
+func (g Game) mapRefs(f refMapper) *Game {
+	g.Season = f(SeasonsCollection, g.Season)
+	g.Venue = f(VenuesCollection, g.Venue)
+	g.HomeTeam = f(TeamsCollection, g.HomeTeam)
+	g.AwayTeam = f(TeamsCollection, g.AwayTeam)
+	return &g
+}
+
+// mapRefs returns a copy of the Venue with references converted by f.
+func (v Venue) mapRefs(f refMapper) *Venue {
+	if v.HomeTeams != nil {
+		teams := make([]*firestore.DocumentRef, len(v.HomeTeams))
+		for i, ref := range v.HomeTeams {
+			teams[i] = f(TeamsCollection, ref)
+		}
+		v.HomeTeams = teams
+	}
+	return &v
+}
+
+// mapRefs returns a copy of the Player with references converted by f.
+func (p Player) mapRefs(f refMapper) *Player {
+	if p.History != nil {
+		picks := make([]PickRecord, len(p.History))
+		for i, pick := range p.History {
+			pick.Season = f(SeasonsCollection, pick.Season)
+			pick.Team = f(TeamsCollection, pick.Team)
+			picks[i] = pick
+		}
+		p.History = picks
+	}
+	if p.RemainingTeams != nil {
+		teams := make([]*firestore.DocumentRef, len(p.RemainingTeams))
+		for i, ref := range p.RemainingTeams {
+			teams[i] = f(TeamsCollection, ref)
+		}
+		p.RemainingTeams = teams
+	}
+	return &p
+}
+
+// matches returns true if the Game passes the filter.
+func (q GameQuery) matches(g *Game) bool {
+	if q.Season != 0 && (g.Season == nil || g.Season.ID != strconv.Itoa(q.Season)) {
+		return false
+	}
+	if g.Week < q.MinWeek {
+		return false
+	}
+	if q.Team != "" && (g.HomeTeam == nil || g.HomeTeam.ID != q.Team) && (g.AwayTeam == nil || g.AwayTeam.ID != q.Team) {
+		return false
+	}
+	return true
+}
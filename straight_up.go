@@ -0,0 +1,181 @@
+package pickem
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// A Pick is the selection of a Team in a Matchup.
+type Pick struct {
+	Matchup *Matchup
+	Team    *Team
+	// Prob is the predicted probability that the pick scores.
+	Prob float64
+	// Spread is the predicted spread in favor of the picked Team.
+	Spread float64
+}
+
+// Picks is a slate of Picks, one per Matchup.
+type Picks []Pick
+
+// ExpectedPoints returns the expected number of picks that score, assuming each is worth one point.
+func (ps Picks) ExpectedPoints() float64 {
+	e := 0.
+	for _, p := range ps {
+		e += p.Prob
+	}
+	return e
+}
+
+// predictSlate predicts the probability of win and spread of the first team of every Matchup in a slate.
+func predictSlate(slate []*Matchup, predicter MatchupPredicter) (probs []float64, spreads []float64, err error) {
+	probs = make([]float64, len(slate))
+	spreads = make([]float64, len(slate))
+	for i, m := range slate {
+		if m == nil || m.Team1 == nil || m.Team2 == nil {
+			return nil, nil, fmt.Errorf("matchup %d of slate is missing a team", i)
+		}
+		if probs[i], spreads[i], err = predicter.Predict(*m); err != nil {
+			return nil, nil, err
+		}
+	}
+	return
+}
+
+// straightUpPick makes a Pick of either the first or second team of the Matchup.
+func straightUpPick(m *Matchup, prob, spread float64, pickTeam1 bool) Pick {
+	if pickTeam1 {
+		return Pick{Matchup: m, Team: m.Team1, Prob: prob, Spread: spread}
+	}
+	return Pick{Matchup: m, Team: m.Team2, Prob: 1 - prob, Spread: -spread}
+}
+
+// StraightUpPicks picks the team most likely to win each Matchup of a slate, which maximizes the expected number of correct picks.
+// If neither team is favored, the first team is picked.
+func StraightUpPicks(slate []*Matchup, predicter MatchupPredicter) (Picks, error) {
+	probs, spreads, err := predictSlate(slate, predicter)
+	if err != nil {
+		return nil, err
+	}
+	picks := make(Picks, len(slate))
+	for i, m := range slate {
+		picks[i] = straightUpPick(m, probs[i], spreads[i], probs[i] >= .5)
+	}
+	return picks, nil
+}
+
+// Field models the opponents of a player in a pick 'em contest.
+type Field struct {
+	// Players is the number of opponents.
+	Players int
+	// PickRates[i] is the probability that an opponent picks the first team of the ith Matchup in the slate.
+	PickRates []float64
+}
+
+// fieldSimulation holds simulated game outcomes and the best score in the field for each simulated week.
+type fieldSimulation struct {
+	// outcomes[s][i] is true if the first team won game i in simulation s.
+	outcomes [][]bool
+	// best[s] is the highest opponent score in simulation s, and nBest[s] the number of opponents with that score.
+	best  []int
+	nBest []int
+}
+
+func simulateField(probs []float64, field Field, sims int, rng *rand.Rand) *fieldSimulation {
+	fs := &fieldSimulation{
+		outcomes: make([][]bool, sims),
+		best:     make([]int, sims),
+		nBest:    make([]int, sims),
+	}
+	for s := 0; s < sims; s++ {
+		outcome := make([]bool, len(probs))
+		for i, p := range probs {
+			outcome[i] = rng.Float64() < p
+		}
+		fs.outcomes[s] = outcome
+
+		for j := 0; j < field.Players; j++ {
+			score := 0
+			for i, rate := range field.PickRates {
+				if (rng.Float64() < rate) == outcome[i] {
+					score++
+				}
+			}
+			switch {
+			case score > fs.best[s]:
+				fs.best[s] = score
+				fs.nBest[s] = 1
+			case score == fs.best[s]:
+				fs.nBest[s]++
+			}
+		}
+	}
+	return fs
+}
+
+// winShare returns the expected share of first place won by the picks, where pickTeam1[i] is true if the first team of game i is picked.
+// Ties for first place split the win evenly.
+func (fs *fieldSimulation) winShare(pickTeam1 []bool) float64 {
+	share := 0.
+	for s, outcome := range fs.outcomes {
+		score := 0
+		for i, pick := range pickTeam1 {
+			if pick == outcome[i] {
+				score++
+			}
+		}
+		switch {
+		case score > fs.best[s]:
+			share++
+		case score == fs.best[s]:
+			share += 1 / float64(fs.nBest[s]+1)
+		}
+	}
+	return share / float64(len(fs.outcomes))
+}
+
+// ContrarianStraightUpPicks picks a team in each Matchup of a slate to maximize the probability of finishing first against
+// a field of opponents, rather than the expected number of correct picks.  Going against the field on close games can
+// be worth more than picking every favorite when the field is large.
+//
+// The probability is estimated by simulating the week the given number of times with a seeded random number generator.
+// Starting from the favorites, single picks are switched as long as doing so improves the estimated share of first place
+// (with ties for first place split evenly).  The Picks and the estimated share of first place are returned.
+func ContrarianStraightUpPicks(slate []*Matchup, predicter MatchupPredicter, field Field, sims int, seed int64) (Picks, float64, error) {
+	if len(field.PickRates) != len(slate) {
+		return nil, 0., fmt.Errorf("field has pick rates for %d games, but slate has %d games", len(field.PickRates), len(slate))
+	}
+	if sims <= 0 {
+		return nil, 0., fmt.Errorf("number of simulations must be positive, got %d", sims)
+	}
+	probs, spreads, err := predictSlate(slate, predicter)
+	if err != nil {
+		return nil, 0., err
+	}
+
+	fs := simulateField(probs, field, sims, rand.New(rand.NewSource(seed)))
+
+	pickTeam1 := make([]bool, len(slate))
+	for i, p := range probs {
+		pickTeam1[i] = p >= .5
+	}
+	best := fs.winShare(pickTeam1)
+	for improved := true; improved; {
+		improved = false
+		for i := range pickTeam1 {
+			pickTeam1[i] = !pickTeam1[i]
+			if share := fs.winShare(pickTeam1); share > best {
+				best = share
+				improved = true
+			} else {
+				pickTeam1[i] = !pickTeam1[i]
+			}
+		}
+	}
+
+	picks := make(Picks, len(slate))
+	for i, m := range slate {
+		picks[i] = straightUpPick(m, probs[i], spreads[i], pickTeam1[i])
+	}
+	return picks, best, nil
+}
@@ -0,0 +1,104 @@
+package pickem
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestStraightUpPicks(t *testing.T) {
+	teamA := fakeTeam("A")
+	teamB := fakeTeam("B")
+	teamC := fakeTeam("C")
+	teamD := fakeTeam("D")
+	rm := map[*Team]float64{teamA: 10, teamB: 3, teamC: 5, teamD: 5}
+	model := NewGaussianSpreadModel(rm, 10, 2, 1)
+
+	slate := []*Matchup{
+		NewMatchup(teamA, teamB, Away),
+		NewMatchup(teamB, teamC, Home),
+		NewMatchup(teamC, teamD, Neutral),
+	}
+	picks, err := StraightUpPicks(slate, model)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		team   *Team
+		spread float64
+	}{
+		{teamA, 5},
+		{teamB, 0},
+		{teamC, 0},
+	}
+	for i, w := range want {
+		if picks[i].Team != w.team {
+			t.Errorf("pick %d: expected %s, got %s", i, w.team.Names[0], picks[i].Team.Names[0])
+		}
+		if picks[i].Spread != w.spread {
+			t.Errorf("pick %d: expected spread %v, got %v", i, w.spread, picks[i].Spread)
+		}
+		if picks[i].Prob < .5 {
+			t.Errorf("pick %d: expected favorite, got probability %v", i, picks[i].Prob)
+		}
+	}
+
+	wantPoints := model.dist.Cdf(5) + model.dist.Cdf(0) + model.dist.Cdf(0)
+	if got := picks.ExpectedPoints(); math.Abs(got-wantPoints) > 1e-12 {
+		t.Errorf("expected %v points, got %v", wantPoints, got)
+	}
+
+	if _, err := StraightUpPicks([]*Matchup{NewMatchup(teamA, nil, Home)}, model); err == nil {
+		t.Errorf("expected error for bye in slate")
+	}
+}
+
+func TestContrarianStraightUpPicks(t *testing.T) {
+	tp := make(tablePredicter)
+	slate := make([]*Matchup, 8)
+	rates := make([]float64, len(slate))
+	for i := range slate {
+		slate[i] = NewMatchup(fakeTeam("Fav"), fakeTeam("Dog"), Home)
+		tp[teamPair{slate[i].Team1, slate[i].Team2}] = .6
+		// The field loves the favorites.
+		rates[i] = .98
+	}
+	field := Field{Players: 100, PickRates: rates}
+
+	picks, share, err := ContrarianStraightUpPicks(slate, tp, field, 5000, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dogs := 0
+	pickTeam1 := make([]bool, len(picks))
+	for i, p := range picks {
+		pickTeam1[i] = p.Team == slate[i].Team1
+		if !pickTeam1[i] {
+			dogs++
+			if math.Abs(p.Prob-.4) > 1e-12 {
+				t.Errorf("expected underdog probability .4, got %v", p.Prob)
+			}
+		}
+	}
+	if dogs == 0 {
+		t.Errorf("expected at least one underdog pick against a chalky field")
+	}
+
+	favorites := make([]bool, len(slate))
+	for i := range favorites {
+		favorites[i] = true
+	}
+	fs := simulateField([]float64{.6, .6, .6, .6, .6, .6, .6, .6}, field, 5000, rand.New(rand.NewSource(1)))
+	if got := fs.winShare(pickTeam1); math.Abs(got-share) > 1e-12 {
+		t.Errorf("expected share %v, got %v", got, share)
+	}
+	if favShare := fs.winShare(favorites); share <= favShare {
+		t.Errorf("expected contrarian share %v to beat favorites share %v", share, favShare)
+	}
+
+	if _, _, err := ContrarianStraightUpPicks(slate, tp, Field{Players: 1, PickRates: rates[:2]}, 10, 1); err == nil {
+		t.Errorf("expected error for mismatched pick rates")
+	}
+}
@@ -0,0 +1,258 @@
+package pickem
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Streak represents a potential streak selection for a contestant.
+type Streak struct {
+	weeks [][]*Team
+}
+
+// NewStreak creates a Streak from a list of teams, a selected number of picks per week, and a permutation of the teams into the week.
+func NewStreak(teamList []*Team, picksPerWeek []int, indexPermutation []int) *Streak {
+	if len(indexPermutation) != len(teamList) {
+		panic(fmt.Errorf("number of teams in list %d must be equal to the indices in the permutation %d", len(teamList), len(indexPermutation)))
+	}
+	picks := make([][]*Team, len(picksPerWeek))
+	i := 0
+	for week, nPicks := range picksPerWeek {
+		picks[week] = make([]*Team, nPicks)
+		for p := 0; p < nPicks; p++ {
+			if i >= len(indexPermutation) {
+				panic(fmt.Errorf("sum total of picks per week must not surpass number of teams remaining %d", len(indexPermutation)))
+			}
+			picks[week][p] = teamList[indexPermutation[i]]
+			i++
+		}
+	}
+	return &Streak{weeks: picks}
+}
+
+// GetWeek returns the teams selected on a given week.  An empty selection means a bye was used.
+func (s *Streak) GetWeek(week int) []*Team {
+	return s.weeks[week]
+}
+
+// FindTeam returns the week in which the given team was selected.
+func (s *Streak) FindTeam(team *Team) int {
+	for week, picks := range s.weeks {
+		for _, pick := range picks {
+			if pick == team {
+				return week
+			}
+		}
+	}
+	return -1
+}
+
+// NumWeeks returns the number of weeks in the streak.
+func (s *Streak) NumWeeks() int {
+	return len(s.weeks)
+}
+
+func (s *Streak) String() string {
+	var out strings.Builder
+	for week, tl := range s.weeks {
+		out.WriteString(fmt.Sprintf("%2d: ", week))
+		for _, t := range tl {
+			out.WriteString(fmt.Sprintf("%-4s ", t.ShortName()))
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+// winProbabilities tabulates the probability that each remaining team wins its Matchup in each week.
+// A team that does not play in a given week has zero probability of winning that week.
+func winProbabilities(remaining []*Team, schedules map[*Team][]*Matchup, nWeeks int, predicter MatchupPredicter) ([][]float64, error) {
+	probs := make([][]float64, len(remaining))
+	for i, team := range remaining {
+		schedule, ok := schedules[team]
+		if !ok {
+			return nil, fmt.Errorf("team '%s' has no schedule", team.Name())
+		}
+		if len(schedule) < nWeeks {
+			return nil, fmt.Errorf("team '%s' has a schedule of %d weeks, but the streak has %d weeks", team.Name(), len(schedule), nWeeks)
+		}
+		probs[i] = make([]float64, nWeeks)
+		for week, m := range schedule[:nWeeks] {
+			if m == nil || m.Team1 == nil || m.Team2 == nil {
+				// Bye week
+				continue
+			}
+			if m.Team1 != team && m.Team2 != team {
+				return nil, fmt.Errorf("team '%s' does not play in its week %d matchup", team.Name(), week)
+			}
+			p, _, err := predicter.Predict(*m)
+			if err != nil {
+				return nil, err
+			}
+			if m.Team2 == team {
+				p = 1 - p
+			}
+			probs[i][week] = p
+		}
+	}
+	return probs, nil
+}
+
+func checkStreakArgs(remaining []*Team, weekTypes *IdenticalPermutor) error {
+	nPicks := 0
+	for _, n := range weekTypes.indices {
+		nPicks += n
+	}
+	if nPicks != len(remaining) {
+		return fmt.Errorf("week types make %d picks, but %d teams remain", nPicks, len(remaining))
+	}
+	return nil
+}
+
+// BestStreak finds the Streak with the maximum probability of picking a winner every week of the season.
+//
+// The remaining teams are the teams the player has yet to pick.  The weekTypes permutor describes the number of
+// picks the player makes each week:  for instance, NewIdenticalPermutor(1, 10, 2) permutes 1 bye week (0 picks),
+// 10 single-pick weeks, and 2 double-down weeks (2 picks) over 13 weeks.
+//
+// Each remaining team must have a schedule of Matchups, one per week in order starting with the first week of the
+// streak, with a nil Matchup or nil opponent for bye weeks.  Teams cannot win during their bye weeks.
+//
+// BestStreak returns the best Streak and its probability.  The search is a depth-first branch-and-bound over weeks:
+// a partial streak is abandoned as soon as its probability, multiplied by the best probability each unpicked team
+// could still have in any remaining week, cannot beat the best complete streak found so far.
+func BestStreak(remaining []*Team, weekTypes *IdenticalPermutor, schedules map[*Team][]*Matchup, predicter MatchupPredicter) (*Streak, float64, error) {
+	if err := checkStreakArgs(remaining, weekTypes); err != nil {
+		return nil, 0., err
+	}
+	probs, err := winProbabilities(remaining, schedules, weekTypes.Len(), predicter)
+	if err != nil {
+		return nil, 0., err
+	}
+
+	ss := newStreakSearch(probs, weekTypes.sets)
+	ss.search(0, 1.)
+
+	picksPerWeek := make([]int, len(ss.bestPicks))
+	perm := make([]int, 0, len(remaining))
+	for week, picks := range ss.bestPicks {
+		picksPerWeek[week] = len(picks)
+		perm = append(perm, picks...)
+	}
+	return NewStreak(remaining, picksPerWeek, perm), ss.best, nil
+}
+
+// streakSearch holds the state of a branch-and-bound search for the best streak.
+type streakSearch struct {
+	// probs[t][w] is the probability of team t winning in week w.
+	probs [][]float64
+	// bestFrom[t][w] is the maximum probability of team t winning in week w or later.
+	bestFrom [][]float64
+	// order[w] lists teams in decreasing order of probability of winning in week w, so good streaks are found early.
+	order [][]int
+	// weekTypes[k] is the number of weeks with k picks not yet assigned.
+	weekTypes []int
+	used      []bool
+	picks     [][]int
+
+	best      float64
+	bestPicks [][]int
+}
+
+func newStreakSearch(probs [][]float64, weekTypes []int) *streakSearch {
+	nWeeks := 0
+	for _, n := range weekTypes {
+		nWeeks += n
+	}
+
+	bestFrom := make([][]float64, len(probs))
+	for t, p := range probs {
+		bestFrom[t] = make([]float64, nWeeks+1)
+		for w := nWeeks - 1; w >= 0; w-- {
+			bestFrom[t][w] = math.Max(p[w], bestFrom[t][w+1])
+		}
+	}
+
+	order := make([][]int, nWeeks)
+	for w := range order {
+		order[w] = make([]int, len(probs))
+		for t := range order[w] {
+			order[w][t] = t
+		}
+		sort.SliceStable(order[w], func(i, j int) bool {
+			return probs[order[w][i]][w] > probs[order[w][j]][w]
+		})
+	}
+
+	return &streakSearch{
+		probs:     probs,
+		bestFrom:  bestFrom,
+		order:     order,
+		weekTypes: clone(weekTypes),
+		used:      make([]bool, len(probs)),
+		picks:     make([][]int, nWeeks),
+		best:      -1.,
+	}
+}
+
+// bound returns the best probability the unpicked teams could contribute from the given week onward.
+func (ss *streakSearch) bound(week int) float64 {
+	b := 1.
+	for t, used := range ss.used {
+		if !used {
+			b *= ss.bestFrom[t][week]
+		}
+	}
+	return b
+}
+
+// search assigns a week type and picks to the given week, then recurses to the next week.
+func (ss *streakSearch) search(week int, p float64) {
+	if week == len(ss.picks) {
+		if p > ss.best {
+			ss.best = p
+			ss.bestPicks = make([][]int, len(ss.picks))
+			for w, picks := range ss.picks {
+				ss.bestPicks[w] = clone(picks)
+			}
+		}
+		return
+	}
+	if p*ss.bound(week) <= ss.best {
+		return
+	}
+	for nPicks, n := range ss.weekTypes {
+		if n == 0 {
+			continue
+		}
+		ss.weekTypes[nPicks]--
+		ss.choose(week, nPicks, 0, p)
+		ss.weekTypes[nPicks]++
+	}
+}
+
+// choose picks nPicks unused teams for the given week, considering only teams at or after position start in the week's order.
+func (ss *streakSearch) choose(week, nPicks, start int, p float64) {
+	if nPicks == 0 {
+		ss.search(week+1, p)
+		return
+	}
+	for i := start; i < len(ss.order[week]); i++ {
+		t := ss.order[week][i]
+		if ss.used[t] {
+			continue
+		}
+		q := p * ss.probs[t][week]
+		if q <= ss.best {
+			// Teams are in decreasing order of probability, so no later team can do better.
+			return
+		}
+		ss.used[t] = true
+		ss.picks[week] = append(ss.picks[week], t)
+		ss.choose(week, nPicks-1, i+1, q)
+		ss.picks[week] = ss.picks[week][:len(ss.picks[week])-1]
+		ss.used[t] = false
+	}
+}
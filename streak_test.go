@@ -1,10 +1,253 @@
 package pickem
 
-// func TestNewStreak(t *testing.T) {
-// 	rem := Remaining{"A", "B", "C", "D"}
-// 	ppw := []int{0, 2, 0, 1, 1}
-// 	itr := []int{1, 2, 3, 0}
-// 	s := NewStreak(rem, ppw, itr)
-
-// 	t.Log(s)
-// }
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// tablePredicter predicts matchups by looking up the probability of win of the first team.
+type tablePredicter map[teamPair]float64
+
+func (tp tablePredicter) Predict(m Matchup) (float64, float64, error) {
+	p, ok := tp[teamPair{m.Team1, m.Team2}]
+	if !ok {
+		return 0, 0, fmt.Errorf("no prediction")
+	}
+	return p, 0, nil
+}
+
+// streakFixture makes 3 remaining teams with 4 weeks of games.  The probabilities of win by week are:
+//
+//	A: .9  .6  .5  .7
+//	B: .8  .9  .6  .5
+//	C: .5  .5  .95 bye
+func streakFixture() ([]*Team, map[*Team][]*Matchup, tablePredicter) {
+	teamA := fakeTeam("A")
+	teamB := fakeTeam("B")
+	teamC := fakeTeam("C")
+	opps := []*Team{fakeTeam("W"), fakeTeam("X"), fakeTeam("Y"), fakeTeam("Z")}
+	probs := map[*Team][]float64{
+		teamA: {.9, .6, .5, .7},
+		teamB: {.8, .9, .6, .5},
+		teamC: {.5, .5, .95, 0},
+	}
+
+	tp := make(tablePredicter)
+	schedules := make(map[*Team][]*Matchup)
+	for team, ps := range probs {
+		schedules[team] = make([]*Matchup, len(ps))
+		for week, p := range ps {
+			if week%2 == 0 {
+				schedules[team][week] = NewMatchup(team, opps[week], Home)
+				tp[teamPair{team, opps[week]}] = p
+			} else {
+				// Opponent listed first
+				schedules[team][week] = NewMatchup(opps[week], team, Away)
+				tp[teamPair{opps[week], team}] = 1 - p
+			}
+		}
+	}
+	schedules[teamC][3] = NewMatchup(teamC, nil, Neutral)
+
+	return []*Team{teamA, teamB, teamC}, schedules, tp
+}
+
+func TestNewStreak(t *testing.T) {
+	rem := []*Team{fakeTeam("A"), fakeTeam("B"), fakeTeam("C"), fakeTeam("D")}
+	ppw := []int{0, 2, 0, 1, 1}
+	itr := []int{1, 2, 3, 0}
+	s := NewStreak(rem, ppw, itr)
+
+	if s.NumWeeks() != 5 {
+		t.Errorf("expected 5 weeks, got %d", s.NumWeeks())
+	}
+	if len(s.GetWeek(0)) != 0 {
+		t.Errorf("expected bye in week 0, got %v", s.GetWeek(0))
+	}
+	if w := s.GetWeek(1); len(w) != 2 || w[0] != rem[1] || w[1] != rem[2] {
+		t.Errorf("expected B and C in week 1, got %v", w)
+	}
+	if s.FindTeam(rem[0]) != 4 {
+		t.Errorf("expected A in week 4, got %d", s.FindTeam(rem[0]))
+	}
+	if s.FindTeam(fakeTeam("E")) != -1 {
+		t.Errorf("expected E not found")
+	}
+}
+
+func TestBestStreak(t *testing.T) {
+	remaining, schedules, tp := streakFixture()
+	teamA, teamB, teamC := remaining[0], remaining[1], remaining[2]
+
+	tests := []struct {
+		name      string
+		weekTypes *IdenticalPermutor
+		want      [][]*Team
+		wantProb  float64
+	}{
+		{name: "one bye",
+			weekTypes: NewIdenticalPermutor(1, 3),
+			want:      [][]*Team{{teamA}, {teamB}, {teamC}, {}},
+			wantProb:  .9 * .9 * .95},
+		{name: "double down",
+			weekTypes: NewIdenticalPermutor(2, 1, 1),
+			want:      [][]*Team{{teamA, teamB}, {}, {teamC}, {}},
+			wantProb:  .9 * .8 * .95},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, p, err := BestStreak(remaining, tt.weekTypes, schedules, tp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(p-tt.wantProb) > 1e-12 {
+				t.Errorf("BestStreak() probability = %v, want %v", p, tt.wantProb)
+			}
+			for week, picks := range tt.want {
+				for _, team := range picks {
+					if got.FindTeam(team) != week {
+						t.Errorf("BestStreak() picked %s in week %d, want %d\n%s", team.Names[0], got.FindTeam(team), week, got)
+					}
+				}
+			}
+		})
+	}
+
+	if _, _, err := BestStreak(remaining, NewIdenticalPermutor(0, 4), schedules, tp); err == nil {
+		t.Errorf("expected error for too many picks")
+	}
+	if _, _, err := BestStreak(append(remaining, fakeTeam("D")), NewIdenticalPermutor(0, 4), schedules, tp); err == nil {
+		t.Errorf("expected error for missing schedule")
+	}
+	if _, _, err := BestStreak(remaining, NewIdenticalPermutor(2, 3), schedules, tp); err == nil {
+		t.Errorf("expected error for short schedule")
+	}
+}
+
+// streakProbability calculates the probability of winning every pick, where teams are picked in the order of perm
+// and picksPerWeek[w] teams are picked in week w.
+func streakProbability(probs [][]float64, picksPerWeek []int, perm []int) float64 {
+	p := 1.
+	i := 0
+	for week, nPicks := range picksPerWeek {
+		for k := 0; k < nPicks; k++ {
+			p *= probs[perm[i]][week]
+			i++
+		}
+	}
+	return p
+}
+
+// bruteForceStreak checks every permutation of teams and week types for the best streak.
+func bruteForceStreak(probs [][]float64, weekTypes *IdenticalPermutor) float64 {
+	best := -1.
+	teamPermutor := NewIndexPermutor(len(probs))
+	for picksPerWeek := range weekTypes.Iterator() {
+		for perm := range teamPermutor.Iterator() {
+			if p := streakProbability(probs, picksPerWeek, perm); p > best {
+				best = p
+			}
+		}
+	}
+	return best
+}
+
+// randomStreakFixture makes nTeams remaining teams with random probabilities of win over nWeeks weeks.
+func randomStreakFixture(rng *rand.Rand, nTeams, nWeeks int) ([]*Team, map[*Team][]*Matchup, tablePredicter, [][]float64) {
+	remaining := make([]*Team, nTeams)
+	schedules := make(map[*Team][]*Matchup)
+	tp := make(tablePredicter)
+	probs := make([][]float64, nTeams)
+	for i := range remaining {
+		team := fakeTeam(fmt.Sprintf("T%d", i))
+		remaining[i] = team
+		schedules[team] = make([]*Matchup, nWeeks)
+		probs[i] = make([]float64, nWeeks)
+		for week := range schedules[team] {
+			if rng.Float64() < .1 {
+				// Bye week
+				continue
+			}
+			opp := fakeTeam(fmt.Sprintf("O%d-%d", i, week))
+			schedules[team][week] = NewMatchup(team, opp, Home)
+			probs[i][week] = rng.Float64()
+			tp[teamPair{team, opp}] = probs[i][week]
+		}
+	}
+	return remaining, schedules, tp, probs
+}
+
+func TestBestStreak_BruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	weekTypes := []*IdenticalPermutor{
+		NewIdenticalPermutor(0, 5),
+		NewIdenticalPermutor(1, 5),
+		NewIdenticalPermutor(2, 3, 1),
+		NewIdenticalPermutor(1, 2, 2),
+	}
+	for i, wt := range weekTypes {
+		nTeams := 0
+		for _, n := range wt.indices {
+			nTeams += n
+		}
+		for trial := 0; trial < 10; trial++ {
+			remaining, schedules, tp, probs := randomStreakFixture(rng, nTeams, wt.Len())
+			s, p, err := BestStreak(remaining, wt, schedules, tp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := bruteForceStreak(probs, wt)
+			if math.Abs(p-want) > 1e-12 {
+				t.Errorf("week types %d trial %d: BestStreak() probability = %v, brute force %v", i, trial, p, want)
+			}
+
+			// The streak must match its probability.
+			got := 1.
+			for week := 0; week < s.NumWeeks(); week++ {
+				for _, team := range s.GetWeek(week) {
+					for j := range remaining {
+						if remaining[j] == team {
+							got *= probs[j][week]
+						}
+					}
+				}
+			}
+			if math.Abs(p-got) > 1e-12 {
+				t.Errorf("week types %d trial %d: BestStreak() streak probability = %v, reported %v", i, trial, got, p)
+			}
+		}
+	}
+}
+
+func TestBestStreak_FullSeason(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	remaining, schedules, tp, probs := randomStreakFixture(rng, 13, 14)
+	got, p, err := BestStreak(remaining, NewIdenticalPermutor(2, 11, 1), schedules, tp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := 1.
+	for i, team := range remaining {
+		week := got.FindTeam(team)
+		if week < 0 {
+			t.Fatalf("BestStreak() did not pick %s\n%s", team.Names[0], got)
+		}
+		want *= probs[i][week]
+	}
+	if p <= 0 || math.Abs(p-want) > 1e-12*want {
+		t.Errorf("BestStreak() probability = %v, want %v from its picks", p, want)
+	}
+}
+
+func BenchmarkBestStreak_FullSeason(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	remaining, schedules, tp, _ := randomStreakFixture(rng, 13, 14)
+	weekTypes := NewIdenticalPermutor(2, 11, 1)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		BestStreak(remaining, weekTypes, schedules, tp)
+	}
+}
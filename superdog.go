@@ -0,0 +1,61 @@
+package pickem
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A SuperdogGame is a Matchup in a superdog contest, where picking the underdog scores the given number of points if the underdog wins.
+type SuperdogGame struct {
+	Matchup  *Matchup
+	Underdog *Team
+	Points   float64
+}
+
+// SuperdogPicks picks the underdogs from a week of SuperdogGames that maximize the expected number of points scored.
+// Contest rules require exactly nPicks picks each week (most contests require one dog per week).
+//
+// The picks are returned in order of decreasing expected points, along with the total expected points of the picks.
+// The Prob of each Pick is the probability that the underdog wins, and the Spread is the predicted spread in favor of the underdog.
+func SuperdogPicks(games []SuperdogGame, predicter MatchupPredicter, nPicks int) (Picks, float64, error) {
+	if nPicks <= 0 || nPicks > len(games) {
+		return nil, 0., fmt.Errorf("number of picks must be between 1 and the number of games (%d), got %d", len(games), nPicks)
+	}
+
+	slate := make([]*Matchup, len(games))
+	for i, g := range games {
+		slate[i] = g.Matchup
+	}
+	probs, spreads, err := predictSlate(slate, predicter)
+	if err != nil {
+		return nil, 0., err
+	}
+
+	picks := make(Picks, len(games))
+	expected := make([]float64, len(games))
+	for i, g := range games {
+		switch g.Underdog {
+		case g.Matchup.Team1:
+			picks[i] = straightUpPick(g.Matchup, probs[i], spreads[i], true)
+		case g.Matchup.Team2:
+			picks[i] = straightUpPick(g.Matchup, probs[i], spreads[i], false)
+		default:
+			return nil, 0., fmt.Errorf("underdog of game %d does not play in the matchup", i)
+		}
+		expected[i] = picks[i].Prob * g.Points
+	}
+
+	order := make([]int, len(games))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return expected[order[i]] > expected[order[j]] })
+
+	best := make(Picks, nPicks)
+	total := 0.
+	for i, o := range order[:nPicks] {
+		best[i] = picks[o]
+		total += expected[o]
+	}
+	return best, total, nil
+}
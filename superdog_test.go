@@ -0,0 +1,61 @@
+package pickem
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSuperdogPicks(t *testing.T) {
+	teamA := fakeTeam("A")
+	teamB := fakeTeam("B")
+	teamC := fakeTeam("C")
+	teamD := fakeTeam("D")
+	teamE := fakeTeam("E")
+	teamF := fakeTeam("F")
+	tp := tablePredicter{
+		teamPair{teamA, teamB}: .8, // B wins .2
+		teamPair{teamC, teamD}: .1, // C wins .1
+		teamPair{teamE, teamF}: .7, // F wins .3
+	}
+	games := []SuperdogGame{
+		{Matchup: NewMatchup(teamA, teamB, Home), Underdog: teamB, Points: 10},
+		{Matchup: NewMatchup(teamC, teamD, Home), Underdog: teamC, Points: 25},
+		{Matchup: NewMatchup(teamE, teamF, Home), Underdog: teamF, Points: 5},
+	}
+
+	picks, points, err := SuperdogPicks(games, tp, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(picks) != 1 || picks[0].Team != teamC {
+		t.Errorf("expected C, got %v", picks)
+	}
+	if math.Abs(points-2.5) > 1e-12 {
+		t.Errorf("expected 2.5 points, got %v", points)
+	}
+	if math.Abs(picks[0].Prob-.1) > 1e-12 {
+		t.Errorf("expected probability .1, got %v", picks[0].Prob)
+	}
+
+	picks, points, err = SuperdogPicks(games, tp, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(picks) != 2 || picks[0].Team != teamC || picks[1].Team != teamB {
+		t.Errorf("expected C and B, got %v", picks)
+	}
+	if math.Abs(points-4.5) > 1e-12 {
+		t.Errorf("expected 4.5 points, got %v", points)
+	}
+
+	if _, _, err := SuperdogPicks(games, tp, 0); err == nil {
+		t.Errorf("expected error for no picks")
+	}
+	if _, _, err := SuperdogPicks(games, tp, 4); err == nil {
+		t.Errorf("expected error for too many picks")
+	}
+	bad := []SuperdogGame{{Matchup: NewMatchup(teamA, teamB, Home), Underdog: teamC, Points: 10}}
+	if _, _, err := SuperdogPicks(bad, tp, 1); err == nil {
+		t.Errorf("expected error for underdog not in matchup")
+	}
+}
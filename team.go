@@ -2,10 +2,10 @@ package pickem
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"cloud.google.com/go/firestore"
-	"google.golang.org/api/iterator"
 )
 
 // A Team in Pick 'Em terms is an entity that participates in Matchups.  Teams have many representations:
@@ -35,50 +35,36 @@ func (t *Team) ShortName() string {
 	return t.Abbreviation
 }
 
-// LookupTeam looks up a team in Firestore by, in order, ID==SchoolName, Abbreviation, then Names.  If multiple teams match, an error is returned.
-func LookupTeam(ctx context.Context, fs *firestore.Client, name string) (*Team, error) {
-	collection := fs.Collection("xteams")
-	var team Team
-
-	doc, err := collection.Doc(name).Get(ctx)
+// LookupTeam looks up a team in a Store by, in order, ID==SchoolName, Abbreviation, then Names.  If multiple teams match, an error is returned.
+func LookupTeam(ctx context.Context, s Store, name string) (*Team, error) {
+	team, err := s.Team(ctx, name)
 	if err == nil {
-		if err := doc.DataTo(&team); err != nil {
-			return nil, err
-		}
-		return &team, nil
+		return team, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
 	}
 
-	itr := collection.Where("Abbreviation", "==", name).Documents(ctx)
-	defer itr.Stop()
-	for {
-		doc, err := itr.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err := doc.DataTo(&team); err != nil {
-			return nil, err
-		}
-		if _, err := itr.Next(); err != iterator.Done {
-			return nil, fmt.Errorf("ambiguous team abbreviation '%s'", name)
-		}
-		return &team, nil
+	teams, err := s.TeamsByAbbreviation(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) > 1 {
+		return nil, fmt.Errorf("ambiguous team abbreviation '%s'", name)
+	}
+	for _, team := range teams {
+		return team, nil
 	}
-	itr.Stop()
 
-	itr = collection.Where("Names", "array-contains", name).Documents(ctx)
-	defer itr.Stop()
-	for {
-		doc, err := itr.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err := doc.DataTo(&team); err != nil {
-			return nil, err
-		}
-		if _, err := itr.Next(); err != iterator.Done {
-			return nil, fmt.Errorf("ambiguous team name '%s'", name)
-		}
-		return &team, nil
+	teams, err = s.TeamsByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) > 1 {
+		return nil, fmt.Errorf("ambiguous team name '%s'", name)
+	}
+	for _, team := range teams {
+		return team, nil
 	}
 
 	return nil, fmt.Errorf("name '%s' not found in teams", name)
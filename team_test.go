@@ -0,0 +1,42 @@
+package pickem
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLookupTeam(t *testing.T) {
+	s := fixtureStore(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		lookup  string
+		want    string
+		wantErr bool
+	}{
+		{name: "by ID", lookup: "Alpha", want: "Alpha"},
+		{name: "by abbreviation", lookup: "BET", want: "Beta"},
+		{name: "by names", lookup: "Gamma Tech", want: "Gamma"},
+		{name: "ID before names", lookup: "Beta", want: "Beta"},
+		{name: "abbreviation before names", lookup: "GAM", want: "Gamma"},
+		{name: "ambiguous abbreviation", lookup: "DEL", wantErr: true},
+		{name: "ambiguous name", lookup: "Delta", wantErr: true},
+		{name: "not found", lookup: "Omega", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LookupTeam(ctx, s, tt.lookup)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LookupTeam() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.SchoolName != tt.want {
+				t.Errorf("LookupTeam() = %v, want %v", got.SchoolName, tt.want)
+			}
+		})
+	}
+}